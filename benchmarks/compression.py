@@ -0,0 +1,73 @@
+# SPDX-FileCopyrightText: Copyright (c) 2023, Dask Developers, NVIDIA
+# SPDX-License-Identifier: BSD 3-Clause License
+"""Benchmark the bytes on the wire when listing a large number of Pods with gzip.
+
+Responses are served from memory with an ``httpx.MockTransport`` which gzips them
+when the client accepts it, like the API server does for large responses. This
+compares the size of the response sent over the network and the time taken to
+decode it with ``compression`` on, the default, and off::
+
+    $ python benchmarks/compression.py --pods 10000
+"""
+import argparse
+import gzip
+import json
+import time
+
+import anyio
+import httpx
+from list_pods import make_pod
+
+import kr8s.asyncio
+
+
+def make_handler(pods: list, sizes: list):
+    body = json.dumps(
+        {"apiVersion": "v1", "kind": "PodList", "metadata": {}, "items": pods}
+    ).encode()
+    compressed = gzip.compress(body)
+
+    def handler(request: httpx.Request) -> httpx.Response:
+        headers = {"Content-Type": "application/json"}
+        if "gzip" in request.headers["Accept-Encoding"]:
+            headers["Content-Encoding"] = "gzip"
+            content = compressed
+        else:
+            content = body
+        sizes.append(len(content))
+        return httpx.Response(200, content=content, headers=headers)
+
+    return handler
+
+
+async def main(n_pods: int, repeat: int) -> None:
+    pods = [make_pod(i) for i in range(n_pods)]
+    results = {}
+    for compression in (True, False):
+        sizes = []
+        api = await kr8s.asyncio.api(
+            url="http://kr8s.test",
+            transport=httpx.MockTransport(make_handler(pods, sizes)),
+            rate_limit=False,
+            compression=compression,
+        )
+        timings = []
+        for _ in range(repeat):
+            start = time.perf_counter()
+            listed = await api.get("pods", namespace=kr8s.ALL)
+            timings.append(time.perf_counter() - start)
+            assert len(listed) == n_pods
+        results[compression] = sizes[-1]
+        print(
+            f"compression={compression!s:>5}: {min(timings):.3f}s, "
+            f"{sizes[-1] / 1e6:.2f}MB on the wire"
+        )
+    print(f"gzip sends {1 - results[True] / results[False]:.0%} fewer bytes")
+
+
+if __name__ == "__main__":
+    parser = argparse.ArgumentParser(description=__doc__.splitlines()[0])
+    parser.add_argument("--pods", type=int, default=10000)
+    parser.add_argument("--repeat", type=int, default=5)
+    args = parser.parse_args()
+    anyio.run(main, args.pods, args.repeat)
@@ -0,0 +1,124 @@
+# SPDX-FileCopyrightText: Copyright (c) 2023, Dask Developers, NVIDIA
+# SPDX-License-Identifier: BSD 3-Clause License
+"""Benchmark listing a large number of Pods.
+
+Responses are served from memory with an ``httpx.MockTransport`` so the results show
+the time kr8s spends decoding responses and building objects rather than the network.
+
+Lists are always decoded from JSON as kr8s can't decode protobuf, so this compares
+full objects with the ``metadata_only`` lists which are the way to reduce the work
+done by the client when only names and labels are needed::
+
+    $ python benchmarks/list_pods.py --pods 5000
+"""
+import argparse
+import json
+import time
+
+import anyio
+import httpx
+
+import kr8s.asyncio
+
+
+def make_pod(i: int) -> dict:
+    name = f"web-{i}"
+    return {
+        "apiVersion": "v1",
+        "kind": "Pod",
+        "metadata": {
+            "name": name,
+            "namespace": f"team-{i % 50}",
+            "uid": f"00000000-0000-0000-0000-{i:012d}",
+            "resourceVersion": str(1000 + i),
+            "creationTimestamp": "2023-01-01T00:00:00Z",
+            "labels": {"app": "web", "pod-template-hash": "5d4f8c9b7"},
+        },
+        "spec": {
+            "nodeName": f"node-{i % 100}",
+            "containers": [
+                {
+                    "name": "web",
+                    "image": "nginx:1.25",
+                    "ports": [{"containerPort": 80, "protocol": "TCP"}],
+                    "resources": {
+                        "requests": {"cpu": "100m", "memory": "128Mi"},
+                        "limits": {"cpu": "500m", "memory": "256Mi"},
+                    },
+                }
+            ],
+        },
+        "status": {
+            "phase": "Running",
+            "podIP": f"10.0.{i // 256 % 256}.{i % 256}",
+            "conditions": [
+                {"type": t, "status": "True"}
+                for t in ("Initialized", "Ready", "ContainersReady", "PodScheduled")
+            ],
+            "containerStatuses": [
+                {"name": "web", "ready": True, "restartCount": 0, "image": "nginx"}
+            ],
+        },
+    }
+
+
+def make_handler(pods: list, sizes: list):
+    full = json.dumps(
+        {"apiVersion": "v1", "kind": "PodList", "metadata": {}, "items": pods}
+    ).encode()
+    metadata = json.dumps(
+        {
+            "apiVersion": "meta.k8s.io/v1",
+            "kind": "PartialObjectMetadataList",
+            "metadata": {},
+            "items": [
+                {
+                    "apiVersion": "meta.k8s.io/v1",
+                    "kind": "PartialObjectMetadata",
+                    "metadata": pod["metadata"],
+                }
+                for pod in pods
+            ],
+        }
+    ).encode()
+
+    def handler(request: httpx.Request) -> httpx.Response:
+        partial = "PartialObjectMetadata" in request.headers["Accept"]
+        body = metadata if partial else full
+        sizes.append(len(body))
+        return httpx.Response(
+            200, content=body, headers={"Content-Type": "application/json"}
+        )
+
+    return handler
+
+
+async def main(n_pods: int, repeat: int) -> None:
+    sizes = []
+    api = await kr8s.asyncio.api(
+        url="http://kr8s.test",
+        transport=httpx.MockTransport(
+            make_handler([make_pod(i) for i in range(n_pods)], sizes)
+        ),
+        rate_limit=False,
+    )
+    for mode, kwargs in [("full", {}), ("metadata_only", {"metadata_only": True})]:
+        timings = []
+        for _ in range(repeat):
+            start = time.perf_counter()
+            pods = await api.get("pods", namespace=kr8s.ALL, **kwargs)
+            timings.append(time.perf_counter() - start)
+            assert len(pods) == n_pods
+        best = min(timings)
+        print(
+            f"{mode:>14}: {best:.3f}s, {n_pods / best:,.0f} pods/s, "
+            f"{sizes[-1] / 1e6:.1f}MB response"
+        )
+
+
+if __name__ == "__main__":
+    parser = argparse.ArgumentParser(description=__doc__.splitlines()[0])
+    parser.add_argument("--pods", type=int, default=5000)
+    parser.add_argument("--repeat", type=int, default=5)
+    args = parser.parse_args()
+    anyio.run(main, args.pods, args.repeat)
@@ -4,11 +4,41 @@ from functools import partial, update_wrapper
 
 import kr8s.objects  # noqa
 
-from ._api import ALL  # noqa
+from ._api import ALL, WatchEvent  # noqa
 from ._api import Api as _AsyncApi
-from ._exceptions import NotFoundError  # noqa
+from ._exceptions import (  # noqa
+    AlreadyExistsError,
+    APIError,
+    BadRequestError,
+    BatchGetError,
+    ConflictError,
+    DisruptionBudgetError,
+    ExecError,
+    ForbiddenError,
+    InvalidError,
+    MetricsUnavailableError,
+    NotFoundError,
+    RequestTimeoutError,
+    ResourceVersionTooOldError,
+    RolloutError,
+    TooManyRequestsError,
+    UnauthorizedError,
+    UnsupportedContentTypeError,
+    is_already_exists,
+    is_conflict,
+    is_forbidden,
+    is_invalid,
+    is_not_found,
+    is_too_many_requests,
+    is_unauthorized,
+)
+from ._data_utils import QuotaUsage, parse_quantity  # noqa
 from ._io import run_sync as _run_sync
 from ._io import sync as _sync  # noqa
+from ._ratelimit import RateLimiter  # noqa
+from ._retry import RetryPolicy  # noqa
+from ._selectors import FieldSelector, LabelSelector  # noqa
+from ._warnings import ServerWarning, WarningCollector  # noqa
 from .asyncio import (
     api as _api,
 )
@@ -18,6 +48,9 @@ from .asyncio import (
 from .asyncio import (
     get as _get,
 )
+from .asyncio import (
+    load_kubeconfig as _load_kubeconfig,
+)
 from .asyncio import (
     version as _version,
 )
@@ -42,3 +75,5 @@ watch = _run_sync(partial(_watch, _asyncio=False))
 update_wrapper(watch, _watch)
 api_resources = _run_sync(partial(_api_resources, _asyncio=False))
 update_wrapper(api_resources, _api_resources)
+load_kubeconfig = _run_sync(_load_kubeconfig)
+update_wrapper(load_kubeconfig, _load_kubeconfig)
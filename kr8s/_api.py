@@ -3,18 +3,122 @@
 from __future__ import annotations
 
 import contextlib
+import copy
+import inspect
 import json
+import re
 import ssl
+import time
+import urllib.parse
+import urllib.request
 import weakref
-from typing import Dict, List, Tuple, Union
+from decimal import Decimal
+from typing import Any, Callable, Dict, List, NamedTuple, Optional, Tuple, Union
 
 import aiohttp
+import anyio
 import httpx
 
 from ._auth import KubeAuth
-from ._data_utils import dict_to_selector
+from ._data_utils import QuotaUsage
+from ._exceptions import (
+    AlreadyExistsError,
+    APIError,
+    BatchGetError,
+    ForbiddenError,
+    MetricsUnavailableError,
+    NotFoundError,
+    RequestTimeoutError,
+    ResourceVersionTooOldError,
+    UnsupportedContentTypeError,
+    api_error_from_response,
+)
+from ._ratelimit import RateLimiter
+from ._retry import RetryPolicy
+from ._selectors import FieldSelector, LabelSelector
+from ._warnings import LogOnceWarningHandler, adapt_warning_handler, parse_warning
 
 ALL = "all"
+# Resources which aren't found only trigger discovery again this many seconds after
+# the last discovery, so lookups of missing kinds don't hammer the API
+DISCOVERY_REFRESH_INTERVAL = 30
+# The maximum number of group versions to discover at once
+DISCOVERY_CONCURRENCY = 16
+# Seconds to wait for a response, long running requests only use this to connect
+DEFAULT_TIMEOUT = 5
+# Prefer JSON, the wildcard is needed for endpoints like logs which serve plain text
+JSON_ACCEPT = "application/json, */*"
+JSON_CONTENT_TYPE = "application/json"
+# Ask for lists of only the metadata, falling back to full objects if unsupported
+PARTIAL_OBJECT_METADATA_ACCEPT = (
+    "application/json;as=PartialObjectMetadataList;v=v1;g=meta.k8s.io,"
+    "application/json"
+)
+
+
+class WatchEvent(NamedTuple):
+    """An event from one of the watches of :meth:`kr8s.Api.watch_many`.
+
+    ``kind`` and ``version`` are those of the resource, e.g ``"Deployment"`` and
+    ``"apps/v1"``, and ``namespace`` is the namespace of the object. If a watch fails
+    the ``type`` is ``"ERROR"`` and the ``object`` is the exception.
+    """
+
+    kind: str
+    version: Optional[str]
+    namespace: Optional[str]
+    type: str
+    object: object
+
+
+def _is_resource_request(version: str, base: str, url: str) -> bool:
+    """Whether a request is for API resources.
+
+    Discovery, version info, OpenAPI schemas and requests proxied through to pods and
+    services aren't resource requests.
+    """
+    if not version or base not in ("", "/api", "/apis"):
+        return False
+    parts = url.split("/")
+    return len(parts) < 3 or parts[2] != "proxy"
+
+
+def _check_content_type(response: httpx.Response) -> None:
+    """Raise if the body of a response can't be decoded as JSON."""
+    header = response.headers.get("Content-Type")
+    if header is None:
+        return
+    media_type = header.split(";")[0].strip().lower()
+    if media_type == JSON_CONTENT_TYPE or media_type.endswith("+json"):
+        return
+    raise UnsupportedContentTypeError(
+        f"Unable to decode {media_type} response from {response.request.url}, "
+        "only JSON responses can be decoded",
+        response=response,
+        content_type=media_type,
+    )
+
+
+def _tighter(usage: QuotaUsage, other: QuotaUsage) -> bool:
+    """Whether a quota will be exhausted before another one."""
+
+    def utilization(u: QuotaUsage):
+        # Nothing more can be used from a zero quota
+        return u.utilization if u.hard else Decimal("Infinity")
+
+    return utilization(usage) > utilization(other)
+
+
+def _parse_version(version: dict) -> Tuple[int, int, int]:
+    """Parse the ``(major, minor, patch)`` version from the server version info."""
+    match = re.match(r"v?(\d+)\.(\d+)\.(\d+)", version.get("gitVersion", ""))
+    if match:
+        return tuple(int(part) for part in match.groups())
+    # Some distributions report minor versions like "28+"
+    major, minor = (
+        int(re.sub(r"\D", "", version.get(key, "")) or 0) for key in ("major", "minor")
+    )
+    return major, minor, 0
 
 
 class Api(object):
@@ -41,14 +145,46 @@ class Api(object):
         self._url = kwargs.get("url")
         self._kubeconfig = kwargs.get("kubeconfig")
         self._serviceaccount = kwargs.get("serviceaccount")
+        self._retry = kwargs.get("retry")
+        if self._retry is None:
+            self._retry = RetryPolicy()
+        elif self._retry is False:
+            self._retry = RetryPolicy(max_attempts=1)
+        self._rate_limit = kwargs.get("rate_limit")
+        if self._rate_limit is None:
+            self._rate_limit = RateLimiter()
+        self._proxy = kwargs.get("proxy")
+        self._transport = kwargs.get("transport")
+        self._compression = kwargs.get("compression") is not False
+        self._trust_system_ca = bool(kwargs.get("trust_system_ca"))
+        self._timeout = kwargs.get("timeout")
+        self._warning_handler = kwargs.get("warning_handler")
+        if self._warning_handler is None:
+            self._warning_handler = LogOnceWarningHandler()
+        elif self._warning_handler:
+            self._warning_handler = adapt_warning_handler(self._warning_handler)
         self._sslcontext = None
         self._session = None
+        # The number of requests using each session which hasn't been closed yet
+        self._session_requests = {}
+        self._impersonate = None
+        self._discovery = None
+        self._discovery_lock = anyio.Lock()
+        self._discovered_at = None
+        self._preferred_versions = None
+        self._server_version = None
+        self._openapi_schemas = {}
+        # Clients from namespaced() share connections and discovery with their parent
+        self._parent = None
+        self._namespace = None
         self.auth = KubeAuth(
             url=self._url,
             kubeconfig=self._kubeconfig,
             serviceaccount=self._serviceaccount,
             namespace=kwargs.get("namespace"),
+            context=kwargs.get("context"),
         )
+        self._factory_kwargs = kwargs
         Api._instances[frozenset(kwargs.items())] = self
 
     def __await__(self):
@@ -58,6 +194,145 @@ class Api(object):
 
         return f().__await__()
 
+    async def with_context(self, context: str) -> Api:
+        """Return a client for another context in the kubeconfig.
+
+        The new client has its own credentials, connections and discovery cache but is
+        otherwise configured like this one. Clients are cached by the factory, so
+        getting the same context again returns the same client.
+
+        Parameters
+        ----------
+        context : str
+            The name of the kubeconfig context.
+
+        Returns
+        -------
+        Api
+            A client for the context.
+
+        Examples
+        --------
+        >>> staging = await api.with_context("staging")
+        >>> pods = await staging.get("pods")
+        """
+        from .asyncio._api import api as _api
+
+        kwargs = {**self._factory_kwargs, "context": context}
+        return await _api(**kwargs, _asyncio=self._asyncio)
+
+    def impersonate(
+        self,
+        user: str = None,
+        groups: List[str] = None,
+        extra: Dict[str, Union[str, List[str]]] = None,
+        uid: str = None,
+        serviceaccount: str = None,
+    ) -> Api:
+        """Return a client which makes requests on behalf of another user.
+
+        The new client shares credentials with this one, which are still used to
+        authenticate each request, but the server authorizes requests as the
+        impersonated user. This client is not modified.
+
+        Parameters
+        ----------
+        user : str, optional
+            The username to impersonate.
+        groups : List[str], optional
+            The groups to impersonate.
+        extra : Dict[str, Union[str, List[str]]], optional
+            Extra fields to impersonate, such as scopes.
+        uid : str, optional
+            The UID of the user to impersonate.
+        serviceaccount : str, optional
+            Impersonate a service account, either ``"<namespace>/<name>"`` or just
+            ``"<name>"`` for one in the client's namespace. Can't be combined with
+            ``user``.
+
+        Returns
+        -------
+        Api
+            A client which impersonates the user.
+
+        Examples
+        --------
+        >>> alice = api.impersonate("alice", groups=["dev"])
+        >>> pods = alice.get("pods")
+        """
+        if serviceaccount is not None:
+            if user is not None:
+                raise ValueError("Specify either user or serviceaccount, not both")
+            namespace, _, name = serviceaccount.rpartition("/")
+            user = f"system:serviceaccount:{namespace or self.namespace}:{name}"
+        if user is None:
+            raise ValueError("A user or serviceaccount to impersonate is required")
+        api = copy.copy(self)
+        api._parent = None
+        api._session = None
+        api._session_requests = {}
+        api._discovery_lock = anyio.Lock()
+        api._impersonate = {
+            "user": user,
+            "groups": list(groups or []),
+            "extra": {
+                key: [value] if isinstance(value, str) else list(value)
+                for key, value in (extra or {}).items()
+            },
+            "uid": uid,
+        }
+        return api
+
+    def namespaced(self, namespace: str) -> Api:
+        """Return a client which uses another namespace by default.
+
+        The new client shares its connections, credentials and discovery cache with
+        this one, so it is cheap to create many of them. Calls can still pass an
+        explicit ``namespace``, and cluster scoped resources ignore the default. This
+        client is not modified.
+
+        Parameters
+        ----------
+        namespace : str
+            The default namespace, or :data:`kr8s.ALL` for all namespaces.
+
+        Returns
+        -------
+        Api
+            A client which defaults to the namespace.
+
+        Examples
+        --------
+        >>> team = api.namespaced("team-a")
+        >>> pods = team.get("pods")
+        >>> nodes = team.get("nodes")
+        >>> pods = team.get("pods", namespace="team-b")
+        """
+        api = copy.copy(self)
+        api._parent = self._root
+        api._namespace = namespace
+        return api
+
+    @property
+    def _root(self) -> Api:
+        """The client which owns the connections and discovery cache."""
+        return self._parent or self
+
+    def _impersonation_headers(self) -> List[Tuple[str, str]]:
+        impersonate = self._impersonate or self.auth.impersonate
+        if not impersonate:
+            return []
+        headers = [("Impersonate-User", impersonate["user"])]
+        headers.extend(("Impersonate-Group", group) for group in impersonate["groups"])
+        for key, values in impersonate["extra"].items():
+            headers.extend(
+                (f"Impersonate-Extra-{urllib.parse.quote(key, safe='')}", value)
+                for value in values
+            )
+        if impersonate.get("uid"):
+            headers.append(("Impersonate-Uid", impersonate["uid"]))
+        return headers
+
     def _load_ssl_context(self) -> None:
         self._sslcontext = ssl.SSLContext(ssl.PROTOCOL_TLS_CLIENT)
         if self.auth.client_key_file:
@@ -66,28 +341,87 @@ class Api(object):
                 keyfile=self.auth.client_key_file,
                 password=None,
             )
+        if self.auth.insecure_skip_tls_verify:
+            self._sslcontext.check_hostname = False
+            self._sslcontext.verify_mode = ssl.CERT_NONE
+            return
         if self.auth.server_ca_file:
             self._sslcontext.load_verify_locations(cafile=self.auth.server_ca_file)
+        if not self.auth.server_ca_file or self._trust_system_ca:
+            self._sslcontext.load_default_certs()
 
     async def _create_session(self) -> None:
+        if self._parent is not None:
+            return await self._parent._create_session()
         headers = {"User-Agent": self.__version__, "content-type": "application/json"}
+        # The API server gzips large responses, httpx decompresses them as they stream
+        headers["Accept-Encoding"] = "gzip" if self._compression else "identity"
+        # Objects are decoded from JSON, so never negotiate protobuf responses
+        headers["Accept"] = JSON_ACCEPT
         self._load_ssl_context()
         if self.auth.token:
             headers["Authorization"] = f"Bearer {self.auth.token}"
-        if self._session:
+        if self._session and self._session not in self._session_requests:
             with contextlib.suppress(RuntimeError):
                 await self._session.aclose()
-            self._session = None
+        # A session which is still in use is closed when its last request finishes
+        self._session = None
         userauth = None
         if self.auth.username and self.auth.password:
             userauth = httpx.BasicAuth(self.auth.username, self.auth.password)
+        transport = self._create_transport()
         self._session = httpx.AsyncClient(
             base_url=self.auth.server,
-            headers=headers,
+            headers=list(headers.items()) + self._impersonation_headers(),
             auth=userauth,
             verify=self._sslcontext,
+            # Proxies are mounted over the transport, so they are configured on the
+            # default transport we wrap instead
+            proxies=self._proxy_url() if transport is None else None,
+            trust_env=self._proxy is None,
+            transport=transport,
         )
 
+    def _create_transport(self) -> Optional[httpx.AsyncBaseTransport]:
+        """The custom transport to send requests with, if one was given.
+
+        A function is called with a default transport configured with the TLS and
+        proxy settings so it can be wrapped. Transport instances are used as they are.
+        """
+        if self._transport is None or isinstance(
+            self._transport, httpx.AsyncBaseTransport
+        ):
+            return self._transport
+        proxy = self._proxy_url()
+        if proxy is None and self._proxy is None:
+            # httpx ignores the environment variables when given a transport
+            proxy = self._env_proxy_url()
+        return self._transport(
+            httpx.AsyncHTTPTransport(
+                verify=self._sslcontext, proxy=httpx.Proxy(proxy) if proxy else None
+            )
+        )
+
+    def _proxy_url(self) -> Optional[str]:
+        """The proxy to connect to the server through.
+
+        When this is ``None`` and no ``proxy`` was given the proxy environment variables
+        are used instead.
+        """
+        if self._proxy is False:
+            return None
+        if callable(self._proxy):
+            return self._proxy(self.auth.server)
+        return self._proxy or self.auth.proxy_url
+
+    def _env_proxy_url(self) -> Optional[str]:
+        """The proxy for the server from the proxy environment variables, if any."""
+        server = urllib.parse.urlparse(self.auth.server)
+        if server.hostname and urllib.request.proxy_bypass(server.hostname):
+            return None
+        proxies = urllib.request.getproxies()
+        return proxies.get(server.scheme) or proxies.get("all")
+
     def _construct_url(
         self,
         version: str = "v1",
@@ -105,11 +439,38 @@ class Api(object):
         parts = [base]
         if version:
             parts.append(version)
-        if namespace is not None:
+        if namespace:
             parts.extend(["namespaces", namespace])
         parts.append(url)
         return "/".join(parts)
 
+    @contextlib.asynccontextmanager
+    async def _use_session(self) -> httpx.AsyncClient:
+        """Use the current session, keeping it open until done even if it is replaced.
+
+        Sessions are replaced when credentials change, for example when a client
+        certificate is rotated, while other requests like watches are still using them.
+        """
+        session = self._session
+        self._session_requests[session] = self._session_requests.get(session, 0) + 1
+        try:
+            yield session
+        finally:
+            self._session_requests[session] -= 1
+            if not self._session_requests[session]:
+                del self._session_requests[session]
+                if session is not self._session:
+                    with contextlib.suppress(RuntimeError):
+                        await session.aclose()
+
+    def _default_timeout(self) -> Optional[float]:
+        """The client's timeout in seconds, or ``None`` to wait forever."""
+        if self._timeout is None:
+            return DEFAULT_TIMEOUT
+        if self._timeout is False:
+            return None
+        return self._timeout
+
     @contextlib.asynccontextmanager
     async def call_api(
         self,
@@ -122,45 +483,143 @@ class Api(object):
         stream: bool = False,
         **kwargs,
     ) -> httpx.Response:
-        """Make a Kubernetes API request."""
-        if not self._session or self._session.is_closed:
+        """Make a Kubernetes API request.
+
+        A ``timeout`` in seconds can be passed to override the client's timeout, or
+        ``None`` or ``False`` to wait forever. Streaming requests like watches only time
+        out while connecting. Requests for API resources also ask the server to give up
+        after the timeout.
+
+        API resources are always requested as JSON unless an ``Accept`` header is
+        given, and a :class:`kr8s.UnsupportedContentTypeError` is raised if the body of
+        a successful response isn't JSON. Other requests accept any content type.
+        """
+        headers = dict(kwargs.get("headers") or {})
+        check_content_type = False
+        if _is_resource_request(version, base, url) and not any(
+            key.lower() == "accept" for key in headers
+        ):
+            headers["Accept"] = JSON_CONTENT_TYPE
+            check_content_type = True
+        kwargs["headers"] = headers
+        if "timeout" in kwargs:
+            timeout = kwargs.pop("timeout")
+            if timeout is False:
+                timeout = None
+            # Only send the timeout to the server when it was chosen by the user
+            server_timeout = timeout is not None
+        else:
+            timeout = self._default_timeout()
+            server_timeout = self._timeout is not None and timeout is not None
+        # Other endpoints don't take a timeout, and proxied requests would pass it on
+        # to the pod or service
+        server_timeout = server_timeout and _is_resource_request(version, base, url)
+        if stream:
+            kwargs["timeout"] = httpx.Timeout(timeout, read=None)
+        else:
+            kwargs["timeout"] = httpx.Timeout(timeout)
+            if server_timeout:
+                # Ask the server to give up at the same time as we do
+                kwargs["params"] = httpx.QueryParams(kwargs.get("params") or {}).set(
+                    "timeout", f"{timeout:g}s"
+                )
+        if self.auth.expired:
+            await self.auth.reauthenticate()
+            await self._create_session()
+        if self.auth.token_stale:
+            await self.auth.reload_token()
+            if self._root._session:
+                self._root._session.headers["Authorization"] = (
+                    f"Bearer {self.auth.token}"
+                )
+        if self.auth.client_cert_stale:
+            # Create a new session so the rotated certificate is loaded
+            self.auth.reload_client_cert()
+            await self._create_session()
+        if not self._root._session or self._root._session.is_closed:
             await self._create_session()
         url = self._construct_url(version, base, namespace, url)
         kwargs.update(url=url, method=method)
-        auth_attempts = 0
-        while True:
-            try:
-                if stream:
-                    async with self._session.stream(**kwargs) as response:
-                        if raise_for_status:
-                            response.raise_for_status()
-                        yield response
-                else:
-                    response = await self._session.request(**kwargs)
-                    if raise_for_status:
-                        response.raise_for_status()
-                    yield response
-            except httpx.HTTPStatusError as e:
-                if e.response.status_code in (401, 403) and auth_attempts < 3:
+        if self.auth.tls_server_name:
+            # Verify the certificate against this name, e.g when the server is an IP
+            kwargs["extensions"] = {
+                **kwargs.get("extensions", {}),
+                "sni_hostname": self.auth.tls_server_name,
+            }
+        # Replaced sessions are kept open until this request is done with them
+        sessions = contextlib.AsyncExitStack()
+        try:
+            auth_attempts = 0
+            attempt = 0
+            while True:
+                attempt += 1
+                if self._rate_limit:
+                    await self._rate_limit.acquire()
+                session = await sessions.enter_async_context(self._root._use_session())
+                request = session.build_request(**kwargs)
+                try:
+                    response = await session.send(request, stream=stream)
+                except RuntimeError as e:
+                    if any(
+                        [
+                            "Event loop is closed" in str(e),
+                            "bound to a different event loop" in str(e),
+                            "attached to a different loop" in str(e),
+                        ]
+                    ):
+                        await self._create_session()
+                        continue
+                    else:
+                        raise
+                except Exception as e:
+                    if attempt < self._retry.max_attempts and (
+                        self._retry.should_retry_exception(method, e)
+                    ):
+                        await anyio.sleep(self._retry.delay(attempt))
+                        continue
+                    if isinstance(e, httpx.TimeoutException):
+                        raise RequestTimeoutError(
+                            f"Timed out after {timeout}s waiting for {method} {url}",
+                            request=request,
+                            timeout=timeout,
+                        ) from e
+                    raise
+                if (
+                    raise_for_status
+                    and response.status_code in (401, 403)
+                    and auth_attempts < 3
+                ):
+                    await response.aclose()
                     auth_attempts += 1
                     await self.auth.reauthenticate()
                     await self._create_session()
                     continue
-                else:
-                    raise
-            except RuntimeError as e:
-                if any(
-                    [
-                        "Event loop is closed" in str(e),
-                        "bound to a different event loop" in str(e),
-                        "attached to a different loop" in str(e),
-                    ]
+                if attempt < self._retry.max_attempts and (
+                    self._retry.should_retry_response(method, response)
                 ):
-                    await self._create_session()
+                    await response.aclose()
+                    await anyio.sleep(self._retry.delay(attempt, response))
                     continue
-                else:
-                    raise
-            break
+                break
+            if self._warning_handler:
+                for header in response.headers.get_list("Warning"):
+                    self._warning_handler(*parse_warning(header))
+            try:
+                if raise_for_status and response.is_error:
+                    if stream:
+                        # Read the body so the Status can be parsed
+                        await response.aread()
+                    try:
+                        response.raise_for_status()
+                    except httpx.HTTPStatusError as e:
+                        raise api_error_from_response(response) from e
+                if check_content_type and not response.is_error:
+                    _check_content_type(response)
+                yield response
+            finally:
+                await response.aclose()
+        finally:
+            await sessions.aclose()
 
     @contextlib.asynccontextmanager
     async def open_websocket(
@@ -172,6 +631,10 @@ class Api(object):
         **kwargs,
     ) -> aiohttp.ClientResponse:
         """Open a websocket connection to a Kubernetes API endpoint."""
+        if self.auth.expired:
+            await self.auth.reauthenticate()
+        if self.auth.token_stale:
+            await self.auth.reload_token()
         headers = {"User-Agent": self.__version__, "content-type": "application/json"}
         self._load_ssl_context()
         if self.auth.token:
@@ -180,14 +643,30 @@ class Api(object):
         if self.auth.username and self.auth.password:
             userauth = aiohttp.BasicAuth(self.auth.username, self.auth.password)
         url = self._construct_url(version, base, namespace, url)
-        kwargs.update(url=url, ssl=self._sslcontext)
+        proxy = self._proxy_url()
+        if proxy and urllib.parse.urlparse(proxy).scheme.startswith("socks"):
+            # aiohttp can only connect through HTTP proxies
+            raise NotImplementedError(
+                f"SOCKS proxies like {proxy} are not supported for websocket "
+                "connections such as exec, attach and port forwarding"
+            )
+        kwargs.update(url=url, ssl=self._sslcontext, proxy=proxy)
+        if self.auth.tls_server_name:
+            kwargs["server_hostname"] = self.auth.tls_server_name
         auth_attempts = 0
         while True:
+            if self._rate_limit:
+                await self._rate_limit.acquire()
             try:
                 async with aiohttp.ClientSession(
                     base_url=self.auth.server,
-                    headers=headers,
+                    headers=list(headers.items()) + self._impersonation_headers(),
                     auth=userauth,
+                    trust_env=self._proxy is None,
+                    # Only time out while connecting, the stream can stay open forever
+                    timeout=aiohttp.ClientTimeout(
+                        total=None, sock_connect=self._default_timeout()
+                    ),
                 ) as session:
                     async with session.ws_connect(**kwargs) as response:
                         yield response
@@ -200,20 +679,103 @@ class Api(object):
                     raise
             break
 
-    async def version(self) -> dict:
+    async def version(self, refresh: bool = False) -> dict:
         """Get the Kubernetes version information from the API.
 
+        The version is cached on the client, so only the first call makes a request.
+
+        Parameters
+        ----------
+        refresh : bool, optional
+            Get the version from the API again, for example after an upgrade.
+
         Returns
         -------
         dict
-            The Kubernetes version information.
+            The Kubernetes version information, including the ``gitVersion``,
+            ``major``, ``minor`` and ``platform`` of the server.
 
         """
-        return await self._version()
+        return await self._version(refresh=refresh)
 
-    async def _version(self) -> dict:
-        async with self.call_api(method="GET", version="", base="/version") as response:
-            return response.json()
+    async def _version(self, refresh: bool = False) -> dict:
+        if self._parent is not None:
+            return await self._parent._version(refresh=refresh)
+        if self._server_version is None or refresh:
+            async with self.call_api(
+                method="GET", version="", base="/version"
+            ) as response:
+                self._server_version = response.json()
+        return self._server_version
+
+    async def version_info(self, refresh: bool = False) -> Tuple[int, int, int]:
+        """Get the Kubernetes version of the server as a tuple of integers.
+
+        This is useful for checking whether the server supports a feature.
+
+        Parameters
+        ----------
+        refresh : bool, optional
+            Get the version from the API again, for example after an upgrade.
+
+        Returns
+        -------
+        tuple
+            The ``(major, minor, patch)`` version of the server.
+
+        Examples
+        --------
+        >>> if await api.version_info() >= (1, 25):
+        ...     await pod.debug("busybox")
+        """
+        return _parse_version(await self._version(refresh=refresh))
+
+    async def openapi_schema(self, group_version: str = None) -> dict:
+        """Get the OpenAPI v3 schema from the API.
+
+        Schemas are cached on the client until the server reports they have changed.
+
+        Parameters
+        ----------
+        group_version : str, optional
+            The group version to get the schema for, e.g ``"v1"`` or ``"apps/v1"``. If
+            not set the index of the schemas for each group version is returned.
+
+        Returns
+        -------
+        dict
+            The OpenAPI v3 document for the group version, or the index of documents.
+
+        Raises
+        ------
+        KeyError
+            If the server doesn't have a schema for the group version.
+        """
+        async with self.call_api(
+            method="GET", version="", base="/openapi", url="v3"
+        ) as response:
+            index = response.json()
+        if group_version is None:
+            return index
+        if "/" in group_version:
+            path = f"apis/{group_version}"
+        else:
+            path = f"api/{group_version}"
+        if path not in index.get("paths", {}):
+            raise KeyError(f"No OpenAPI schema for {group_version}")
+        server_url = index["paths"][path]["serverRelativeURL"]
+        if server_url not in self._openapi_schemas:
+            url = urllib.parse.urlsplit(server_url)
+            async with self.call_api(
+                method="GET",
+                version="",
+                base="/openapi/v3",
+                url=path,
+                params=urllib.parse.parse_qsl(url.query) or None,
+            ) as response:
+                # The URL contains a hash of the schema so it is safe to cache
+                self._openapi_schemas[server_url] = response.json()
+        return self._openapi_schemas[server_url]
 
     async def reauthenticate(self) -> None:
         """Reauthenticate the API."""
@@ -224,37 +786,42 @@ class Api(object):
         self,
         kind: str,
         namespace: str = None,
-        label_selector: Union[str, Dict] = None,
-        field_selector: Union[str, Dict] = None,
+        label_selector: Union[str, Dict, LabelSelector] = None,
+        field_selector: Union[str, Dict, FieldSelector] = None,
         params: dict = None,
         watch: bool = False,
+        metadata_only: bool = False,
         **kwargs,
     ) -> dict:
         """Get a Kubernetes resource."""
-        from ._objects import get_class
-
         if not namespace:
             namespace = self.namespace
         if namespace is ALL:
-            namespace = ""
+            # List across all namespaces with the cluster wide collection URL
+            namespace = None
         if params is None:
             params = {}
         if label_selector:
             if isinstance(label_selector, dict):
-                label_selector = dict_to_selector(label_selector)
-            params["labelSelector"] = label_selector
+                label_selector = LabelSelector.from_dict(label_selector)
+            params["labelSelector"] = str(label_selector)
         if field_selector:
             if isinstance(field_selector, dict):
-                field_selector = dict_to_selector(field_selector)
-            params["fieldSelector"] = field_selector
+                field_selector = FieldSelector.from_dict(field_selector)
+            params["fieldSelector"] = str(field_selector)
         if watch:
             params["watch"] = "true" if watch else "false"
             kwargs["stream"] = True
+        if metadata_only:
+            kwargs["headers"] = {
+                **(kwargs.get("headers") or {}),
+                "Accept": PARTIAL_OBJECT_METADATA_ACCEPT,
+            }
         params = params or None
-        obj_cls = get_class(kind, _asyncio=self._asyncio)
+        obj_cls = await self._lookup_class(kind)
         async with self.call_api(
             method="GET",
-            url=kind,
+            url=obj_cls.endpoint,
             version=obj_cls.version,
             namespace=namespace if obj_cls.namespaced else None,
             params=params,
@@ -267,9 +834,12 @@ class Api(object):
         kind: str,
         *names: List[str],
         namespace: str = None,
-        label_selector: Union[str, Dict] = None,
-        field_selector: Union[str, Dict] = None,
+        label_selector: Union[str, Dict, LabelSelector] = None,
+        field_selector: Union[str, Dict, FieldSelector] = None,
         as_object: object = None,
+        limit: int = None,
+        metadata_only: bool = False,
+        strip_managed_fields: bool = False,
         **kwargs,
     ) -> List[object]:
         """
@@ -283,12 +853,24 @@ class Api(object):
             The names of specific resources to get.
         namespace : str, optional
             The namespace to get the resource from.
-        label_selector : Union[str, Dict], optional
+        label_selector : Union[str, Dict, LabelSelector], optional
             The label selector to filter the resources by.
-        field_selector : Union[str, Dict], optional
+        field_selector : Union[str, Dict, FieldSelector], optional
             The field selector to filter the resources by.
         as_object : object, optional
             The object to return the resources as.
+        limit : int, optional
+            Fetch the resources in pages of at most this many items. Pages are
+            requested until the server stops returning a continue token and the
+            results are concatenated. If the continue token expires the list is
+            restarted from the beginning.
+        metadata_only : bool, optional
+            Only fetch the metadata of the resources, which are returned as
+            :class:`kr8s.objects.PartialObjectMetadata` objects. This is much
+            cheaper for large lists when only names, labels or owner references are
+            needed.
+        strip_managed_fields : bool, optional
+            Remove ``metadata.managedFields`` from the resources to save memory.
         **kwargs
             Additional keyword arguments to pass to the API call.
 
@@ -304,6 +886,9 @@ class Api(object):
             label_selector=label_selector,
             field_selector=field_selector,
             as_object=as_object,
+            limit=limit,
+            metadata_only=metadata_only,
+            strip_managed_fields=strip_managed_fields,
             **kwargs,
         )
 
@@ -312,11 +897,28 @@ class Api(object):
         kind: str,
         *names: List[str],
         namespace: str = None,
-        label_selector: Union[str, Dict] = None,
-        field_selector: Union[str, Dict] = None,
+        label_selector: Union[str, Dict, LabelSelector] = None,
+        field_selector: Union[str, Dict, FieldSelector] = None,
         as_object: object = None,
+        limit: int = None,
+        metadata_only: bool = False,
+        strip_managed_fields: bool = False,
         **kwargs,
     ) -> List[object]:
+        if as_object and metadata_only:
+            raise ValueError("as_object and metadata_only can't be used together")
+        if limit is not None and not as_object:
+            return await self._get_pages(
+                kind,
+                *names,
+                namespace=namespace,
+                label_selector=label_selector,
+                field_selector=field_selector,
+                limit=limit,
+                metadata_only=metadata_only,
+                strip_managed_fields=strip_managed_fields,
+                **kwargs,
+            )
         headers = {}
         if as_object:
             group, version = as_object.version.split("/")
@@ -329,6 +931,7 @@ class Api(object):
             label_selector=label_selector,
             field_selector=field_selector,
             headers=headers or None,
+            metadata_only=metadata_only,
             **kwargs,
         ) as (obj_cls, response):
             resourcelist = response.json()
@@ -339,29 +942,275 @@ class Api(object):
             ):
                 return as_object(resourcelist, api=self)
             else:
-                if "items" in resourcelist:
-                    return [
-                        obj_cls(item, api=self)
-                        for item in resourcelist["items"]
-                        if not names or item["metadata"]["name"] in names
-                    ]
-                return []
+                return [
+                    obj
+                    for obj in self._list_objects(
+                        obj_cls, resourcelist, strip_managed_fields
+                    )
+                    if not names or obj.name in names
+                ]
+
+    def _list_objects(
+        self, obj_cls: type, resourcelist: dict, strip_managed_fields: bool = False
+    ) -> List[object]:
+        """Create objects from the items of a list response."""
+        from ._objects import get_class
+
+        if resourcelist.get("kind") == "PartialObjectMetadataList":
+            obj_cls = get_class(
+                "PartialObjectMetadata", "meta.k8s.io/v1", _asyncio=self._asyncio
+            )
+        objects = []
+        for item in resourcelist.get("items") or []:
+            if strip_managed_fields:
+                item.get("metadata", {}).pop("managedFields", None)
+            objects.append(obj_cls(item, api=self))
+        return objects
+
+    async def get_all(
+        self, refs: List[Dict[str, str]], max_concurrency: int = 10
+    ) -> List[object]:
+        """Get many objects of any kind concurrently.
+
+        Requests are made concurrently, up to ``max_concurrency`` at a time, and are
+        still subject to the client's rate limit.
+
+        Parameters
+        ----------
+        refs : List[dict]
+            References to the objects to get, each with a ``kind``, ``name`` and
+            optionally an ``apiVersion`` and ``namespace``. Object references from the
+            Kubernetes API such as ``ownerReferences`` can be used directly.
+        max_concurrency : int, optional
+            The maximum number of requests to make at once.
+
+        Returns
+        -------
+        list
+            The objects, in the same order as the references.
+
+        Raises
+        ------
+        BatchGetError
+            If any of the objects couldn't be fetched. The objects which were fetched
+            are available as ``results`` and the failures as ``errors``.
+
+        Examples
+        --------
+        >>> pod, config = await api.get_all(
+        ...     [
+        ...         {"kind": "Pod", "name": "web", "namespace": "default"},
+        ...         {"apiVersion": "v1", "kind": "ConfigMap", "name": "web-config"},
+        ...     ]
+        ... )
+        """
+        results = [None] * len(refs)
+        errors = {}
+        limiter = anyio.CapacityLimiter(max_concurrency)
+
+        async def get_ref(index: int, ref: Dict[str, str]) -> None:
+            async with limiter:
+                try:
+                    kind, version = ref["kind"], ref.get("apiVersion")
+                    obj_cls = await self._lookup_class(kind, version)
+                    metadata = {"name": ref["name"]}
+                    if ref.get("namespace") and obj_cls.namespaced:
+                        metadata["namespace"] = ref["namespace"]
+                    obj = obj_cls({"metadata": metadata}, api=self)
+                    await obj._refresh()
+                except Exception as e:
+                    errors[index] = (ref, e)
+                else:
+                    results[index] = obj
+
+        async with anyio.create_task_group() as tg:
+            for index, ref in enumerate(refs):
+                tg.start_soon(get_ref, index, ref)
+        if errors:
+            errors = [errors[index] for index in sorted(errors)]
+            raise BatchGetError(
+                f"Failed to get {len(errors)} of {len(refs)} objects: "
+                + ", ".join(f"{ref['kind']}/{ref['name']}: {e}" for ref, e in errors),
+                results=results,
+                errors=errors,
+            )
+        return results
+
+    async def _get_pages(
+        self, kind: str, *names: List[str], limit: int, **kwargs
+    ) -> List[object]:
+        from ._pager import ListPager
+
+        max_restarts = 3
+        for attempt in range(max_restarts + 1):
+            pager = ListPager(self, kind, limit=limit, **kwargs)
+            objects = []
+            try:
+                async for page in pager.pages():
+                    objects.extend(
+                        obj for obj in page if not names or obj.name in names
+                    )
+            except ResourceVersionTooOldError:
+                # The continue token expired part way through, start again
+                if attempt == max_restarts:
+                    raise
+                continue
+            return objects
+
+    def pager(
+        self,
+        kind: str,
+        namespace: str = None,
+        label_selector: Union[str, Dict, LabelSelector] = None,
+        field_selector: Union[str, Dict, FieldSelector] = None,
+        limit: int = 500,
+        restart_on_expired: bool = False,
+        metadata_only: bool = False,
+        strip_managed_fields: bool = False,
+        **kwargs,
+    ):
+        """
+        List Kubernetes resources one page at a time.
+
+        Parameters
+        ----------
+        kind : str
+            The kind of resource to list.
+        namespace : str, optional
+            The namespace to list the resources in.
+        label_selector : Union[str, Dict, LabelSelector], optional
+            The label selector to filter the resources by.
+        field_selector : Union[str, Dict, FieldSelector], optional
+            The field selector to filter the resources by.
+        limit : int, optional
+            The maximum number of resources in each page. Defaults to 500.
+        restart_on_expired : bool, optional
+            Start again from the first page if the continue token expires instead of
+            raising :class:`kr8s.ResourceVersionTooOldError`.
+        metadata_only : bool, optional
+            Only fetch the metadata of the resources, see :meth:`get`.
+        strip_managed_fields : bool, optional
+            Remove ``metadata.managedFields`` from the resources to save memory.
+        **kwargs
+            Additional keyword arguments to pass to the API call.
+
+        Returns
+        -------
+        ListPager
+            A pager with a ``next()`` method returning each page in turn.
+        """
+        if self._asyncio:
+            from kr8s.asyncio.pager import ListPager
+        else:
+            from kr8s.pager import ListPager
+        return ListPager(
+            self,
+            kind,
+            namespace=namespace,
+            label_selector=label_selector,
+            field_selector=field_selector,
+            limit=limit,
+            restart_on_expired=restart_on_expired,
+            metadata_only=metadata_only,
+            strip_managed_fields=strip_managed_fields,
+            **kwargs,
+        )
+
+    def informer(
+        self,
+        kind: str,
+        namespace: str = None,
+        label_selector: Union[str, Dict, LabelSelector] = None,
+        field_selector: Union[str, Dict, FieldSelector] = None,
+        resync_period: float = None,
+    ):
+        """
+        Create an informer which keeps a local cache of resources in sync with a watch.
+
+        Informers run in the background alongside other tasks so they are only
+        available with the async API.
+
+        Parameters
+        ----------
+        kind : str
+            The kind of resource to cache.
+        namespace : str, optional
+            The namespace to cache the resources in.
+        label_selector : Union[str, Dict, LabelSelector], optional
+            The label selector to filter the resources by.
+        field_selector : Union[str, Dict, FieldSelector], optional
+            The field selector to filter the resources by.
+        resync_period : float, optional
+            Seconds between calling the update handlers for every cached object.
+
+        Returns
+        -------
+        Informer
+            An informer, use it as an async context manager to start it.
+        """
+        if not self._asyncio:
+            raise NotImplementedError("Informers are only supported by kr8s.asyncio")
+        from kr8s.asyncio.informer import Informer
+
+        return Informer(
+            self,
+            kind,
+            namespace=namespace,
+            label_selector=label_selector,
+            field_selector=field_selector,
+            resync_period=resync_period,
+        )
 
     async def watch(
         self,
         kind: str,
         namespace: str = None,
-        label_selector: Union[str, Dict] = None,
-        field_selector: Union[str, Dict] = None,
+        label_selector: Union[str, Dict, LabelSelector] = None,
+        field_selector: Union[str, Dict, FieldSelector] = None,
         since: str = None,
+        allow_bookmarks: bool = False,
+        reconnect: bool = False,
     ):
-        """Watch a Kubernetes resource."""
+        """Watch a Kubernetes resource.
+
+        Yields ``(event_type, object)`` tuples where ``event_type`` is one of
+        ``ADDED``, ``MODIFIED``, ``DELETED`` or ``BOOKMARK``.
+
+        Parameters
+        ----------
+        kind : str
+            The kind of resource to watch.
+        namespace : str, optional
+            The namespace to watch the resource in.
+        label_selector : Union[str, Dict, LabelSelector], optional
+            The label selector to filter the resources by.
+        field_selector : Union[str, Dict, FieldSelector], optional
+            The field selector to filter the resources by.
+        since : str, optional
+            The resource version to start watching from.
+        allow_bookmarks : bool, optional
+            Ask the server to send ``BOOKMARK`` events. These contain an object with only
+            ``metadata.resourceVersion`` set which can be persisted and passed back via
+            ``since`` to resume the watch later.
+        reconnect : bool, optional
+            Keep watching from the last seen resource version when the connection drops
+            or the server closes the watch, which it does every few minutes. By default
+            the watch ends when the server closes it and a dropped connection raises.
+
+        Raises
+        ------
+        ResourceVersionTooOldError
+            If the resource version being watched from is no longer available and the
+            resources need to be listed again.
+        """
         async for t, object in self._watch(
             kind,
             namespace=namespace,
             label_selector=label_selector,
             field_selector=field_selector,
             since=since,
+            allow_bookmarks=allow_bookmarks,
+            reconnect=reconnect,
         ):
             yield t, object
 
@@ -369,22 +1218,437 @@ class Api(object):
         self,
         kind: str,
         namespace: str = None,
-        label_selector: Union[str, Dict] = None,
-        field_selector: Union[str, Dict] = None,
+        label_selector: Union[str, Dict, LabelSelector] = None,
+        field_selector: Union[str, Dict, FieldSelector] = None,
         since: str = None,
+        allow_bookmarks: bool = False,
+        reconnect: bool = False,
     ) -> Tuple[str, object]:
         """Watch a Kubernetes resource."""
-        async with self._get_kind(
-            kind,
-            namespace=namespace,
-            label_selector=label_selector,
-            field_selector=field_selector,
-            params={"resourceVersion": since} if since else None,
-            watch=True,
-        ) as (obj_cls, response):
-            async for line in response.aiter_lines():
-                event = json.loads(line)
-                yield event["type"], obj_cls(event["object"], api=self)
+        backoff = 0.1
+        while True:
+            params = {}
+            if since:
+                params["resourceVersion"] = since
+            if allow_bookmarks:
+                params["allowWatchBookmarks"] = "true"
+            try:
+                async with self._get_kind(
+                    kind,
+                    namespace=namespace,
+                    label_selector=label_selector,
+                    field_selector=field_selector,
+                    params=params,
+                    watch=True,
+                ) as (obj_cls, response):
+                    async for line in response.aiter_lines():
+                        event = json.loads(line)
+                        if (
+                            event["type"] == "ERROR"
+                            and event["object"].get("code") == 410
+                        ):
+                            raise ResourceVersionTooOldError(
+                                event["object"].get("message"), status=event["object"]
+                            )
+                        obj = obj_cls(event["object"], api=self)
+                        since = obj.raw["metadata"].get("resourceVersion", since)
+                        backoff = 0.1
+                        yield event["type"], obj
+            except httpx.TransportError:
+                if not reconnect:
+                    raise
+                await anyio.sleep(backoff)
+                backoff = min(backoff * 2, 5)
+                continue
+            if not reconnect:
+                break
+
+    async def watch_many(
+        self, watches: List[Union[str, Dict]], buffer_size: int = 100
+    ) -> WatchEvent:
+        """Watch several kinds of resource at once.
+
+        Yields a :class:`WatchEvent` for each event from any of the watches, tagged
+        with the ``kind``, ``version`` and ``namespace`` of the object. Each watch
+        reconnects independently if its connection drops, and if its resource version
+        expires it is started again, which sends ``ADDED`` events for all of the
+        current resources. Watching continues until the caller stops iterating.
+
+        Errors don't stop the other watches. Transient errors, such as ``429``,
+        ``500`` or ``503`` responses, are retried with backoff. If a watch fails
+        otherwise, for example because listing one of the kinds is forbidden or the
+        kind doesn't exist, an ``"ERROR"`` event is yielded with the exception as its
+        ``object`` and only that watch stops.
+
+        Events from all of the watches share a buffer of ``buffer_size`` events. When
+        the consumer falls behind and the buffer fills up the watches wait for it
+        rather than dropping events, each watch is delayed fairly and none of them
+        are disconnected.
+
+        Parameters
+        ----------
+        watches : List[Union[str, Dict]]
+            The kinds to watch, either as strings or as dicts with a ``kind`` and any
+            of the other arguments of :meth:`watch` like ``namespace`` or
+            ``label_selector``.
+        buffer_size : int, optional
+            The number of events to buffer for a slow consumer.
+
+        Examples
+        --------
+        >>> async for event in api.watch_many(
+        ...     ["pods", "events", {"kind": "deployments", "namespace": "web"}]
+        ... ):
+        ...     if event.type == "ERROR":
+        ...         raise event.object
+        ...     print(event.kind, event.type, event.object.name)
+        """
+        async for event in self._watch_many(watches, buffer_size=buffer_size):
+            yield event
+
+    async def _watch_many(
+        self, watches: List[Union[str, Dict]], buffer_size: int = 100
+    ) -> WatchEvent:
+        send, receive = anyio.create_memory_object_stream(buffer_size)
+
+        async def run(watch: Union[str, Dict]) -> None:
+            kwargs = {"kind": watch} if isinstance(watch, str) else dict(watch)
+            name = kind = kwargs.pop("kind")
+            since = kwargs.pop("since", None)
+            kwargs.setdefault("reconnect", True)
+            namespace = kwargs.get("namespace") or self.namespace
+            namespace = None if namespace is ALL else namespace
+            try:
+                obj_cls = await self._lookup_class(name)
+            except (APIError, KeyError) as e:
+                await send.send(WatchEvent(kind, None, namespace, "ERROR", e))
+                return
+            kind, version = obj_cls.kind, obj_cls.version
+            attempt = 0
+            while True:
+                try:
+                    async for event, obj in self._watch(name, since=since, **kwargs):
+                        since = obj.raw["metadata"].get("resourceVersion", since)
+                        attempt = 0
+                        await send.send(
+                            WatchEvent(kind, version, obj.namespace, event, obj)
+                        )
+                    return
+                except ResourceVersionTooOldError:
+                    since = None
+                except APIError as e:
+                    if e.code not in self._retry.status_codes:
+                        # Errors like 401, 403 or 404 won't go away by retrying
+                        await send.send(
+                            WatchEvent(kind, version, namespace, "ERROR", e)
+                        )
+                        return
+                    attempt += 1
+                    await anyio.sleep(self._retry.delay(attempt, e.response))
+
+        async with anyio.create_task_group() as tg:
+            for watch in watches:
+                tg.start_soon(run, watch)
+            try:
+                async with receive:
+                    async for event in receive:
+                        yield event
+            finally:
+                tg.cancel_scope.cancel()
+
+    async def apply(
+        self,
+        resource: Union[dict, object],
+        field_manager: str = "kr8s",
+        force: bool = False,
+        dry_run: bool = False,
+        field_validation: str = None,
+    ) -> object:
+        """Server-side apply a Kubernetes resource.
+
+        Parameters
+        ----------
+        resource : Union[dict, object]
+            The resource to apply, either a resource spec or a kr8s object.
+        field_manager : str, optional
+            The name of the manager that owns the applied fields.
+        force : bool, optional
+            Take ownership of fields owned by other field managers instead of raising a conflict.
+        dry_run : bool, optional
+            Validate the request on the server without persisting it.
+        field_validation : str, optional
+            How the server handles unknown or duplicate fields, one of ``"Strict"``,
+            ``"Warn"`` or ``"Ignore"``.
+
+        Returns
+        -------
+        object
+            The server's merged result as a kr8s object.
+
+        Raises
+        ------
+        ConflictError
+            If applied fields are owned by another field manager and ``force`` is not set.
+        """
+        return await self._apply(
+            resource,
+            field_manager=field_manager,
+            force=force,
+            dry_run=dry_run,
+            field_validation=field_validation,
+        )
+
+    async def _apply(
+        self,
+        resource: Union[dict, object],
+        field_manager: str = "kr8s",
+        force: bool = False,
+        dry_run: bool = False,
+        field_validation: str = None,
+    ) -> object:
+        resource = await self._as_object(resource)
+        return await resource._apply(
+            field_manager=field_manager,
+            force=force,
+            dry_run=dry_run,
+            field_validation=field_validation,
+        )
+
+    async def _as_object(self, resource: Union[dict, object]) -> object:
+        """Create a kr8s object from a resource spec, objects are returned as is."""
+        from ._objects import new_class
+
+        if not isinstance(resource, dict):
+            return resource
+        try:
+            obj_cls = await self._lookup_class(
+                resource["kind"], resource["apiVersion"]
+            )
+        except KeyError:
+            obj_cls = new_class(
+                resource["kind"], resource["apiVersion"], asyncio=self._asyncio
+            )
+        return obj_cls(resource, api=self)
+
+    async def get_or_create(
+        self, resource: Union[dict, object]
+    ) -> Tuple[object, bool]:
+        """Get an object, creating it if it doesn't exist.
+
+        An existing object is returned as it is in Kubernetes, it isn't changed to
+        match ``resource``.
+
+        Parameters
+        ----------
+        resource : Union[dict, object]
+            The object to get or create, either a resource spec or a kr8s object.
+
+        Returns
+        -------
+        Tuple[object, bool]
+            The object and whether it was created.
+
+        Examples
+        --------
+        >>> namespace, created = await api.get_or_create(
+        ...     {"apiVersion": "v1", "kind": "Namespace", "metadata": {"name": "dev"}}
+        ... )
+        """
+        return await self._get_or_create(resource)
+
+    async def _get_or_create(
+        self, resource: Union[dict, object]
+    ) -> Tuple[object, bool]:
+        obj = await self._as_object(resource)
+        try:
+            await obj._refresh()
+            return obj, False
+        except NotFoundError:
+            pass
+        try:
+            await obj._create()
+            return obj, True
+        except AlreadyExistsError:
+            # Created by someone else since we looked
+            await obj._refresh()
+            return obj, False
+
+    async def create_or_update(
+        self,
+        resource: Union[dict, object],
+        mutate: Callable[[object], Any],
+        retry: RetryPolicy = None,
+    ) -> Tuple[object, bool]:
+        """Update an object if it exists, otherwise create it.
+
+        If the object exists ``mutate`` is called with the latest version of it to make
+        changes to ``raw`` in place, and then it is updated with the
+        ``resourceVersion`` that was read. Conflicting writes are retried as with
+        ``APIObject.update_with_retry``. If the object doesn't exist ``mutate`` is
+        called with ``resource`` instead before it is created, so the same function
+        sets the desired state either way.
+
+        Parameters
+        ----------
+        resource : Union[dict, object]
+            The object to create or update, either a resource spec or a kr8s object.
+        mutate : Callable
+            A function or coroutine function which makes changes to the object.
+        retry : RetryPolicy, optional
+            Controls retrying an update which conflicts with another writer.
+
+        Returns
+        -------
+        Tuple[object, bool]
+            The object and whether it was created.
+
+        Examples
+        --------
+        >>> def set_data(config_map):
+        ...     config_map.raw["data"] = {"debug": "true"}
+        >>> config_map, created = await api.create_or_update(
+        ...     {"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "app"}},
+        ...     set_data,
+        ... )
+        """
+        return await self._create_or_update(resource, mutate, retry=retry)
+
+    async def _create_or_update(
+        self,
+        resource: Union[dict, object],
+        mutate: Callable[[object], Any],
+        retry: RetryPolicy = None,
+    ) -> Tuple[object, bool]:
+        obj = await self._as_object(resource)
+        metadata = {"name": obj.name}
+        if obj.namespaced:
+            metadata["namespace"] = obj.namespace
+        existing = obj.__class__({"metadata": metadata}, api=self)
+        try:
+            await existing._update_with_retry(mutate, retry=retry)
+            return existing, False
+        except NotFoundError:
+            pass
+        result = mutate(obj)
+        if inspect.isawaitable(result):
+            await result
+        try:
+            await obj._create()
+            return obj, True
+        except AlreadyExistsError:
+            # Created by someone else since we looked, update theirs instead
+            await existing._update_with_retry(mutate, retry=retry)
+            return existing, False
+
+    async def top_nodes(
+        self, label_selector: Union[str, Dict, LabelSelector] = None
+    ) -> List[object]:
+        """Get the current CPU and memory usage of nodes, like ``kubectl top nodes``.
+
+        Usage is read from the ``metrics.k8s.io`` API which is served by metrics-server.
+
+        Parameters
+        ----------
+        label_selector : str, dict or LabelSelector, optional
+            Only get the usage of nodes matching this selector.
+
+        Returns
+        -------
+        list
+            A ``NodeMetrics`` object for each node, see ``NodeMetrics.usage``.
+
+        Raises
+        ------
+        MetricsUnavailableError
+            If the metrics API isn't available.
+        """
+        return await self._top("nodemetrics", label_selector=label_selector)
+
+    async def top_pods(
+        self,
+        namespace: str = None,
+        label_selector: Union[str, Dict, LabelSelector] = None,
+    ) -> List[object]:
+        """Get the current CPU and memory usage of Pods, like ``kubectl top pods``.
+
+        Usage is read from the ``metrics.k8s.io`` API which is served by metrics-server.
+
+        Parameters
+        ----------
+        namespace : str, optional
+            The namespace to get Pods from, use ``kr8s.ALL`` for all namespaces.
+            Defaults to the namespace of the client.
+        label_selector : str, dict or LabelSelector, optional
+            Only get the usage of Pods matching this selector, for example the
+            selector of a Deployment.
+
+        Returns
+        -------
+        list
+            A ``PodMetrics`` object for each Pod, see ``PodMetrics.usage`` and
+            ``PodMetrics.containers``.
+
+        Raises
+        ------
+        MetricsUnavailableError
+            If the metrics API isn't available.
+        """
+        return await self._top(
+            "podmetrics", namespace=namespace, label_selector=label_selector
+        )
+
+    async def quota_usage(
+        self, namespace: str = None, threshold: float = None
+    ) -> Dict[str, QuotaUsage]:
+        """Get how much of each resource quota in a namespace is used.
+
+        When several quotas limit the same resource the one with the highest
+        utilization is returned, as that is the one which will be exceeded first.
+
+        Parameters
+        ----------
+        namespace : str, optional
+            The namespace to get quotas for. Defaults to the namespace of the client.
+        threshold : float, optional
+            Only return resources whose utilization has reached this fraction of the
+            quota, e.g ``0.8`` for resources which are at least 80% used.
+
+        Returns
+        -------
+        dict
+            A :class:`kr8s.QuotaUsage` of the ``used`` and ``hard`` quantities, keyed
+            by resource name such as ``"requests.cpu"``.
+
+        Examples
+        --------
+        >>> for resource, usage in (await api.quota_usage(threshold=0.9)).items():
+        ...     print(f"{resource} is {usage.utilization:.0%} used")
+        """
+        quotas = await self._get("resourcequotas", namespace=namespace)
+        usage = {}
+        for quota in quotas:
+            for resource, quota_usage in quota.usage.items():
+                current = usage.get(resource)
+                if current is None or _tighter(quota_usage, current):
+                    usage[resource] = quota_usage
+        if threshold is not None:
+            usage = {k: v for k, v in usage.items() if v.over(threshold)}
+        return usage
+
+    async def _top(self, kind: str, **kwargs) -> List[object]:
+        """Get metrics, raising a clear error if the metrics API isn't available."""
+        try:
+            return await self._get(kind, **kwargs)
+        except APIError as e:
+            if e.code in (404, 503):
+                raise MetricsUnavailableError(
+                    "The metrics API is not available, "
+                    f"check that metrics-server is installed: {e}",
+                    request=e.request,
+                    response=e.response,
+                    status=e.status,
+                ) from e
+            raise
 
     async def api_resources(self) -> dict:
         """Get the Kubernetes API resources."""
@@ -392,38 +1656,167 @@ class Api(object):
 
     async def _api_resources(self) -> dict:
         """Get the Kubernetes API resources."""
+        discovery = await self._server_resources()
         resources = []
-        async with self.call_api(method="GET", version="", base="/api") as response:
-            core_api_list = response.json()
-
-        for version in core_api_list["versions"]:
-            async with self.call_api(
-                method="GET", version="", base="/api", url=version
-            ) as response:
-                resource = response.json()
+        for version in self._root._preferred_versions:
             resources.extend(
                 [
                     {"version": version, **r}
-                    for r in resource["resources"]
+                    for r in discovery[version]["resources"]
                     if "/" not in r["name"]
                 ]
             )
+        return resources
+
+    async def server_resources(self, refresh: bool = False) -> Dict[str, dict]:
+        """Get the resources served by each API group version.
+
+        The results of discovery are cached on the client, so only the first call makes
+        requests to the API. This can be called at startup to warm up the cache.
+
+        Parameters
+        ----------
+        refresh : bool, optional
+            Discover the resources again, for example after installing a CRD.
+
+        Returns
+        -------
+        dict
+            The ``APIResourceList`` for each group version, keyed by group version.
+        """
+        return await self._server_resources(refresh=refresh)
+
+    async def _server_resources(self, refresh: bool = False) -> Dict[str, dict]:
+        """Get the resources served by each API group version."""
+        if self._parent is not None:
+            return await self._parent._server_resources(refresh=refresh)
+        if self._discovery is not None and not refresh:
+            return self._discovery
+        discovered_at = self._discovered_at
+        async with self._discovery_lock:
+            if self._discovery is not None and self._discovered_at != discovered_at:
+                # Another task discovered the resources while we waited for the lock
+                return self._discovery
+            return await self._discover_resources()
+
+    async def _discover_resources(self) -> Dict[str, dict]:
+        """Discover the resources served by each API group version."""
+        preferred = []
+        async with self.call_api(method="GET", version="", base="/api") as response:
+            core_versions = response.json()["versions"]
+        group_versions = [("/api", version) for version in core_versions]
+        preferred.extend(core_versions)
         async with self.call_api(method="GET", version="", base="/apis") as response:
-            api_list = response.json()
-        for api in sorted(api_list["groups"], key=lambda d: d["name"]):
-            version = api["versions"][0]["groupVersion"]
-            async with self.call_api(
-                method="GET", version="", base="/apis", url=version
-            ) as response:
-                resource = response.json()
-            resources.extend(
-                [
-                    {"version": version, **r}
-                    for r in resource["resources"]
-                    if "/" not in r["name"]
-                ]
+            groups = response.json()["groups"]
+        for group in sorted(groups, key=lambda d: d["name"]):
+            preferred_version = group.get("preferredVersion", group["versions"][0])
+            preferred.append(preferred_version["groupVersion"])
+            group_versions.extend(
+                [("/apis", version["groupVersion"]) for version in group["versions"]]
+            )
+        results = {}
+        limiter = anyio.CapacityLimiter(DISCOVERY_CONCURRENCY)
+
+        async def discover(base: str, version: str) -> None:
+            async with limiter:
+                try:
+                    async with self.call_api(
+                        method="GET", version="", base=base, url=version
+                    ) as response:
+                        results[version] = response.json()
+                except (ForbiddenError, NotFoundError):
+                    # Group versions we can't see, or which have just been removed
+                    pass
+
+        async with anyio.create_task_group() as tg:
+            for base, version in group_versions:
+                tg.start_soon(discover, base, version)
+        discovery = {
+            version: results[version]
+            for _, version in group_versions
+            if version in results
+        }
+        self._discovery = discovery
+        self._discovered_at = time.monotonic()
+        self._preferred_versions = [v for v in preferred if v in discovery]
+        return discovery
+
+    @property
+    def _discovery_stale(self) -> bool:
+        """Whether enough time has passed to discover missing resources again."""
+        return (
+            self._discovered_at is None
+            or time.monotonic() - self._discovered_at >= DISCOVERY_REFRESH_INTERVAL
+        )
+
+    async def _lookup_resource(self, kind: str, version: str = None) -> dict:
+        """Find a resource by kind, plural, singular or short name using discovery.
+
+        The kind can be qualified with its group, e.g ``deployments.apps``. If the
+        resource isn't found the discovery cache is refreshed in case it has just been
+        installed, unless it was refreshed within ``DISCOVERY_REFRESH_INTERVAL``.
+
+        Raises
+        ------
+        KeyError
+            If the resource isn't served by the API.
+        """
+        group = None
+        if version is None and "." in kind:
+            kind, group = kind.split(".", 1)
+        for refresh in (False, True):
+            if refresh and not self._root._discovery_stale:
+                break
+            discovery = await self._server_resources(refresh=refresh)
+            preferred = self._root._preferred_versions
+            versions = preferred + [v for v in discovery if v not in preferred]
+            for group_version in versions:
+                if version is not None and group_version != version:
+                    continue
+                if group is not None and group_version.rpartition("/")[0] != group:
+                    continue
+                resources = discovery[group_version]["resources"]
+                names = {r["name"] for r in resources}
+                for resource in resources:
+                    if "/" in resource["name"]:
+                        continue
+                    if kind in (
+                        resource["kind"],
+                        resource["name"],
+                        resource.get("singularName"),
+                        *resource.get("shortNames", []),
+                    ) or kind.lower() == resource["kind"].lower():
+                        return {
+                            "version": group_version,
+                            "status_subresource": f"{resource['name']}/status" in names,
+                            "scale_subresource": f"{resource['name']}/scale" in names,
+                            **resource,
+                        }
+        raise KeyError(f"Unable to find resource {kind} in the API")
+
+    async def _lookup_class(self, kind: str, version: str = None) -> type:
+        """Get the object class for a kind, creating one from discovery if needed."""
+        from ._objects import get_class, new_class
+
+        try:
+            return get_class(kind, version, _asyncio=self._asyncio)
+        except KeyError:
+            pass
+        resource = await self._lookup_resource(kind, version)
+        try:
+            return get_class(
+                resource["kind"], resource["version"], _asyncio=self._asyncio
+            )
+        except KeyError:
+            return new_class(
+                resource["kind"],
+                resource["version"],
+                asyncio=self._asyncio,
+                namespaced=resource["namespaced"],
+                plural=resource["name"],
+                scalable_subresource=resource["scale_subresource"],
+                status_subresource=resource["status_subresource"],
             )
-        return resources
 
     @property
     def __version__(self) -> str:
@@ -434,8 +1827,13 @@ class Api(object):
     @property
     def namespace(self) -> str:
         """Get the default namespace."""
+        if self._namespace is not None:
+            return self._namespace
         return self.auth.namespace
 
     @namespace.setter
     def namespace(self, value):
-        self.auth.namespace = value
+        if self._namespace is not None:
+            self._namespace = value
+        else:
+            self.auth.namespace = value
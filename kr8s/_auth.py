@@ -1,17 +1,138 @@
 # SPDX-FileCopyrightText: Copyright (c) 2023, Dask Developers, NVIDIA
 # SPDX-License-Identifier: BSD 3-Clause License
 import base64
+import datetime
+import functools
 import json
 import os
+import re
+import ssl
+import stat
+import subprocess
+import sys
+import time
 
 import anyio
+import httpx
 import yaml
 
-from ._io import NamedTemporaryFile, check_output
+from ._io import NamedTemporaryFile
+
+DEFAULT_KUBECONFIG = "~/.kube/config"
+DEFAULT_SERVICEACCOUNT = "/var/run/secrets/kubernetes.io/serviceaccount"
+# Projected service account tokens are rotated by the kubelet, so they are read from
+# disk again once they are this many seconds old
+TOKEN_RELOAD_INTERVAL = 60
+# Client certificate files are checked for rotation at most this often, in seconds
+CLIENT_CERT_CHECK_INTERVAL = 10
+# OIDC id tokens are refreshed when they expire within this many seconds
+OIDC_REFRESH_MARGIN = 60
+
+EXEC_API_VERSIONS = (
+    "client.authentication.k8s.io/v1",
+    "client.authentication.k8s.io/v1beta1",
+)
+
+
+def _parse_timestamp(timestamp: str) -> datetime.datetime:
+    """Parse an RFC3339 timestamp as used by Kubernetes."""
+    timestamp = re.sub(r"[Zz]$", "+00:00", timestamp)
+    # Before Python 3.11 fromisoformat only accepts 3 or 6 digit fractions, but Go
+    # writes RFC3339Nano timestamps with up to 9 digits
+    timestamp = re.sub(
+        r"\.(\d+)", lambda m: "." + m.group(1)[:6].ljust(6, "0"), timestamp, count=1
+    )
+    return datetime.datetime.fromisoformat(timestamp)
+
+
+def _jwt_expiry(token: str) -> datetime.datetime:
+    """Read the ``exp`` claim of a JWT without verifying it.
+
+    Tokens which can't be parsed or don't have an expiry are treated as expired.
+    """
+    try:
+        payload = token.split(".")[1]
+        payload += "=" * (-len(payload) % 4)
+        claims = json.loads(base64.urlsafe_b64decode(payload))
+        return datetime.datetime.fromtimestamp(claims["exp"], datetime.timezone.utc)
+    except (AttributeError, IndexError, KeyError, TypeError, ValueError):
+        return datetime.datetime.min.replace(tzinfo=datetime.timezone.utc)
+
+
+async def load_kubeconfig(*paths) -> dict:
+    """Load and merge kubeconfig files, following the same rules as ``kubectl``.
+
+    Clusters, users and contexts are merged by name, with the first file to define a
+    name winning, and the ``current-context`` comes from the first file to set one.
+    Relative file paths in each file are resolved relative to that file's directory.
+    Files which don't exist are skipped.
+
+    Args:
+        *paths: Paths to kubeconfig files, in order of precedence.
+
+    Returns:
+        The merged kubeconfig.
+    """
+    merged = {"clusters": [], "contexts": [], "users": []}
+    for path in paths:
+        path = os.path.abspath(os.path.expanduser(path))
+        if not os.path.isfile(path):
+            continue
+        async with await anyio.open_file(path) as f:
+            config = yaml.safe_load(await f.read()) or {}
+        _resolve_kubeconfig_paths(config, os.path.dirname(path))
+        if "current-context" not in merged and config.get("current-context"):
+            merged["current-context"] = config["current-context"]
+        for key in ("clusters", "contexts", "users"):
+            names = {item["name"] for item in merged[key]}
+            for item in config.get(key) or []:
+                if item["name"] not in names:
+                    merged[key].append(item)
+                    names.add(item["name"])
+    return merged
+
+
+def _resolve_kubeconfig_paths(config: dict, base: str) -> None:
+    """Make file paths in a kubeconfig absolute, relative to the directory it is in."""
+
+    def resolve(path: str) -> str:
+        return os.path.join(base, os.path.expanduser(path))
+
+    for cluster in config.get("clusters") or []:
+        cluster = cluster.get("cluster") or {}
+        if "certificate-authority" in cluster:
+            cluster["certificate-authority"] = resolve(cluster["certificate-authority"])
+    for user in config.get("users") or []:
+        user = user.get("user") or {}
+        for key in ("client-certificate", "client-key", "tokenFile"):
+            if key in user:
+                user[key] = resolve(user[key])
+        provider = (user.get("auth-provider") or {}).get("config") or {}
+        if "idp-certificate-authority" in provider:
+            provider["idp-certificate-authority"] = resolve(
+                provider["idp-certificate-authority"]
+            )
+        command = (user.get("exec") or {}).get("command")
+        if command and os.sep in command:
+            user["exec"]["command"] = resolve(command)
 
 
 class KubeAuth:
-    """Load kubernetes auth from kubeconfig, service account, or url."""
+    """Load kubernetes auth from kubeconfig, service account, or url.
+
+    Credentials are loaded from the first of these sources that is found:
+
+    1. The ``url``, if one is given.
+    2. The ``kubeconfig`` file, if one is given.
+    3. The ``serviceaccount`` directory, if one is given.
+    4. The kubeconfig file in the ``KUBECONFIG`` environment variable.
+    5. The in-cluster service account, when running in a Pod.
+    6. The kubeconfig file at ``~/.kube/config``.
+
+    Passing a ``kubeconfig`` or ``serviceaccount`` replaces its default sources, and
+    passing ``False`` disables them altogether. Kubeconfig files use their
+    ``current-context`` unless a ``context`` is given.
+    """
 
     def __init__(
         self,
@@ -19,6 +140,7 @@ class KubeAuth:
         url=None,
         serviceaccount=None,
         namespace=None,
+        context=None,
     ) -> None:
         self.server = None
         self.client_cert_file = None
@@ -28,16 +150,33 @@ class KubeAuth:
         self.username = None
         self.password = None
         self.namespace = namespace
+        self.impersonate = None
+        self.proxy_url = None
+        self.insecure_skip_tls_verify = False
+        self.tls_server_name = None
         self._context = None
+        self._context_name = context
         self._cluster = None
         self._user = None
+        self._exec_credential = None
+        self._exec_expiry = None
+        self._oidc_expiry = None
+        self._oidc_tokens = None
+        self._oidc_lock = anyio.Lock()
+        self._token_file = None
+        self._token_loaded_at = None
+        self._client_cert_mtimes = None
+        self._client_cert_checked_at = None
+        self._serviceaccount_arg = serviceaccount
+        self._kubeconfig_arg = kubeconfig
         self._serviceaccount = (
-            serviceaccount
-            if serviceaccount is not None
-            else "/var/run/secrets/kubernetes.io/serviceaccount"
+            serviceaccount if serviceaccount is not None else DEFAULT_SERVICEACCOUNT
+        )
+        self._kubeconfig = kubeconfig or os.environ.get(
+            "KUBECONFIG", DEFAULT_KUBECONFIG
         )
-        self._kubeconfig = kubeconfig or os.environ.get("KUBECONFIG", "~/.kube/config")
 
+        self._url = url
         if url:
             self.server = url
 
@@ -50,28 +189,61 @@ class KubeAuth:
 
     async def reauthenticate(self) -> None:
         """Reauthenticate with the server."""
-        if self._serviceaccount and not self.server:
-            await self._load_service_account()
-        if self._kubeconfig is not False and not self.server:
-            await self._load_kubeconfig()
+        self.server = self._url
+        self.proxy_url = None
+        self.insecure_skip_tls_verify = False
+        self.tls_server_name = None
+        self._exec_credential = None
+        self._oidc_expiry = None
+        self._token_file = None
+        self._client_cert_mtimes = None
+        for load, path in self._credential_sources():
+            if self.server:
+                break
+            await load(path)
         if not self.server:
             raise ValueError("Unable to find valid credentials")
 
-    async def _load_kubeconfig(self) -> None:
+    def _credential_sources(self) -> list:
+        """The loaders and paths to try, in order, when looking for credentials."""
+        sources = []
+        if self._kubeconfig_arg:
+            sources.append((self._load_kubeconfig, self._kubeconfig_arg))
+        if self._serviceaccount_arg:
+            sources.append((self._load_service_account, self._serviceaccount_arg))
+        if self._kubeconfig_arg is None and os.environ.get("KUBECONFIG"):
+            sources.append((self._load_kubeconfig, os.environ["KUBECONFIG"]))
+        if self._serviceaccount_arg is None:
+            sources.append((self._load_service_account, DEFAULT_SERVICEACCOUNT))
+        if self._kubeconfig_arg is None:
+            sources.append((self._load_kubeconfig, DEFAULT_KUBECONFIG))
+        return sources
+
+    async def _load_kubeconfig(self, path) -> None:
         """Load kubernetes auth from kubeconfig."""
-        self._kubeconfig = os.path.expanduser(self._kubeconfig)
-        if not os.path.exists(self._kubeconfig):
+        config = await load_kubeconfig(*str(path).split(os.pathsep))
+        if not config["contexts"]:
             return
-        async with await anyio.open_file(self._kubeconfig) as f:
-            config = yaml.safe_load(await f.read())
-        if "current-context" in config:
+        self._kubeconfig = path
+        if self._context_name:
+            contexts = [
+                c["context"]
+                for c in config["contexts"]
+                if c["name"] == self._context_name
+            ]
+            if not contexts:
+                raise ValueError(
+                    f"Context {self._context_name} not found in kubeconfig {path}"
+                )
+            [self._context] = contexts
+        elif "current-context" in config:
             [self._context] = [
                 c["context"]
                 for c in config["contexts"]
                 if c["name"] == config["current-context"]
             ]
         else:
-            self.context = config["contexts"][0]["context"]
+            self._context = config["contexts"][0]["context"]
 
         [self._cluster] = [
             c["cluster"]
@@ -83,30 +255,17 @@ class KubeAuth:
         ]
 
         self.server = self._cluster["server"]
+        self.proxy_url = self._cluster.get("proxy-url")
+        self.insecure_skip_tls_verify = bool(
+            self._cluster.get("insecure-skip-tls-verify", False)
+        )
+        self.tls_server_name = self._cluster.get("tls-server-name")
 
-        if "exec" in self._user:
-            if (
-                self._user["exec"]["apiVersion"]
-                != "client.authentication.k8s.io/v1beta1"
-            ):
+        for field in ("client-certificate", "client-key"):
+            if field in self._user and f"{field}-data" in self._user:
                 raise ValueError(
-                    "Only client.authentication.k8s.io/v1beta1 is supported for exec auth"
+                    f"Only one of {field} and {field}-data can be set for a user"
                 )
-            command = self._user["exec"]["command"]
-            args = self._user["exec"].get("args", [])
-            env = os.environ.copy()
-            env.update(
-                **{e["name"]: e["value"] for e in self._user["exec"].get("env", [])}
-            )
-            data = json.loads(await check_output(command, *args, env=env))["status"]
-            if "token" in data:
-                self._user["token"] = data["token"]
-            elif "clientCertificateData" in data and "clientKeyData" in data:
-                self._user["client-certificate-data"] = data["clientCertificateData"]
-                self._user["client-key-data"] = data["clientKeyData"]
-            else:
-                raise KeyError(f"Did not find credentials in {command} output.")
-
         if "client-key-data" in self._user:
             async with NamedTemporaryFile(delete=False) as key_file:
                 await key_file.write_bytes(
@@ -119,37 +278,328 @@ class KubeAuth:
                     base64.b64decode(self._user["client-certificate-data"])
                 )
                 self.client_cert_file = str(cert_file)
+        if "client-key" in self._user:
+            self.client_key_file = self._user["client-key"]
+        if "client-certificate" in self._user:
+            self.client_cert_file = self._user["client-certificate"]
+        if bool(self.client_cert_file) != bool(self.client_key_file):
+            raise ValueError("A client certificate and key must be set together")
+        if "client-certificate" in self._user or "client-key" in self._user:
+            # Certificates from files may be rotated while we are running
+            self.reload_client_cert()
+        if "certificate-authority" in self._cluster:
+            self.server_ca_file = self._cluster["certificate-authority"]
         if "certificate-authority-data" in self._cluster:
             async with NamedTemporaryFile(delete=False) as ca_file:
                 await ca_file.write_bytes(
                     base64.b64decode(self._cluster["certificate-authority-data"])
                 )
                 self.server_ca_file = str(ca_file)
+        if "tokenFile" in self._user:
+            async with await anyio.open_file(self._user["tokenFile"]) as f:
+                self.token = (await f.read()).strip()
         if "token" in self._user:
             self.token = self._user["token"]
         if "username" in self._user:
             self.username = self._user["username"]
         if "password" in self._user:
             self.password = self._user["password"]
+        if "exec" in self._user:
+            await self._load_exec_credential()
+        if (self._user.get("auth-provider") or {}).get("name") == "oidc":
+            await self._load_oidc_token()
+        if "as" in self._user:
+            self.impersonate = {
+                "user": self._user["as"],
+                "groups": self._user.get("as-groups") or [],
+                "extra": self._user.get("as-user-extra") or {},
+                "uid": self._user.get("as-uid"),
+            }
         if self.namespace is None:
             self.namespace = self._context.get("namespace", "default")
-        # TODO: Handle auth-provider oidc auth
 
-    async def _load_service_account(self) -> None:
+    @property
+    def expired(self) -> bool:
+        """Whether the credentials from an exec plugin or OIDC provider have expired."""
+        now = datetime.datetime.now(datetime.timezone.utc)
+        if self._oidc_expiry is not None and (
+            now + datetime.timedelta(seconds=OIDC_REFRESH_MARGIN) >= self._oidc_expiry
+        ):
+            return True
+        if self._exec_expiry is None:
+            return False
+        return now >= self._exec_expiry
+
+    async def _load_oidc_token(self) -> None:
+        """Use the OIDC id token from the kubeconfig, refreshing it if it has expired.
+
+        Refreshed tokens are written back to the kubeconfig file the user came from.
+        """
+        config = self._user["auth-provider"].get("config") or {}
+        # Only refresh once when several requests find the token has expired
+        async with self._oidc_lock:
+            id_token = config.get("id-token")
+            refresh_token = config.get("refresh-token")
+            if self._oidc_tokens:
+                # Tokens we refreshed may not have been saved to the kubeconfig
+                cached_id_token, cached_refresh_token = self._oidc_tokens
+                if _jwt_expiry(cached_id_token) > _jwt_expiry(id_token):
+                    id_token, refresh_token = cached_id_token, cached_refresh_token
+            margin = datetime.timedelta(seconds=OIDC_REFRESH_MARGIN)
+            now = datetime.datetime.now(datetime.timezone.utc)
+            if refresh_token and _jwt_expiry(id_token) <= now + margin:
+                id_token, refresh_token = await self._refresh_oidc_token(
+                    config, refresh_token
+                )
+                self._oidc_tokens = (id_token, refresh_token)
+                await self._write_oidc_tokens(id_token, refresh_token)
+            if not id_token:
+                raise ValueError("OIDC auth-provider has no id-token or refresh-token")
+            self.token = id_token
+            if refresh_token:
+                self._oidc_expiry = _jwt_expiry(id_token)
+
+    async def _refresh_oidc_token(self, config: dict, refresh_token: str) -> tuple:
+        """Get a new id token from the OIDC provider with the refresh token."""
+        for key in ("idp-issuer-url", "client-id"):
+            if key not in config:
+                raise ValueError(f"OIDC auth-provider is missing {key}")
+        verify = True
+        ca_data = config.get("idp-certificate-authority-data")
+        if ca_data or config.get("idp-certificate-authority"):
+            verify = ssl.create_default_context(
+                cafile=config.get("idp-certificate-authority"),
+                cadata=base64.b64decode(ca_data).decode() if ca_data else None,
+            )
+        issuer = config["idp-issuer-url"].rstrip("/")
+        async with httpx.AsyncClient(verify=verify) as client:
+            response = await client.get(f"{issuer}/.well-known/openid-configuration")
+            response.raise_for_status()
+            data = {
+                "grant_type": "refresh_token",
+                "refresh_token": refresh_token,
+                "client_id": config["client-id"],
+            }
+            if config.get("client-secret"):
+                data["client_secret"] = config["client-secret"]
+            response = await client.post(response.json()["token_endpoint"], data=data)
+            response.raise_for_status()
+        tokens = response.json()
+        if "id_token" not in tokens:
+            raise ValueError("OIDC provider did not return an id_token")
+        # Providers may or may not rotate the refresh token
+        return tokens["id_token"], tokens.get("refresh_token", refresh_token)
+
+    async def _write_oidc_tokens(self, id_token: str, refresh_token: str) -> None:
+        """Save refreshed OIDC tokens to the kubeconfig file which defines the user."""
+        if not self._kubeconfig:
+            return
+        user_name = self._context["user"]
+        for path in str(self._kubeconfig).split(os.pathsep):
+            path = os.path.expanduser(path)
+            try:
+                async with await anyio.open_file(path) as f:
+                    config = yaml.safe_load(await f.read()) or {}
+            except OSError:
+                continue
+            for user in config.get("users") or []:
+                if user.get("name") != user_name:
+                    continue
+                provider = (user.get("user") or {}).get("auth-provider") or {}
+                if provider.get("name") != "oidc":
+                    return
+                provider.setdefault("config", {}).update(
+                    {"id-token": id_token, "refresh-token": refresh_token}
+                )
+                try:
+                    await self._replace_file(path, yaml.safe_dump(config))
+                except OSError:
+                    pass  # The new tokens are still kept in memory
+                return
+
+    @staticmethod
+    async def _replace_file(path: str, data: str) -> None:
+        """Atomically replace the contents of a file, keeping its mode."""
+        # Replace the file a symlink points to rather than the symlink itself
+        path = os.path.realpath(path)
+        mode = stat.S_IMODE((await anyio.Path(path).stat()).st_mode)
+        async with NamedTemporaryFile(
+            dir=os.path.dirname(path), prefix=".kubeconfig-", delete=False
+        ) as tmp:
+            try:
+                await tmp.write_text(data)
+                await tmp.chmod(mode)
+                await tmp.replace(path)
+            except OSError:
+                await tmp.unlink(missing_ok=True)
+                raise
+
+    async def _load_exec_credential(self) -> None:
+        """Run a client-go exec credential plugin and use the credentials it returns.
+
+        See https://kubernetes.io/docs/reference/access-authn-authz/authentication/#client-go-credential-plugins
+        """
+        config = self._user["exec"]
+        api_version = config.get("apiVersion")
+        if api_version not in EXEC_API_VERSIONS:
+            raise ValueError(
+                f"Exec auth apiVersion {api_version} is not supported, "
+                f"expected one of {', '.join(EXEC_API_VERSIONS)}"
+            )
+        if self._exec_credential is None or self.expired:
+            self._exec_credential = await self._run_exec_plugin(config)
+        status = self._exec_credential["status"]
+        expiry = status.get("expirationTimestamp")
+        self._exec_expiry = _parse_timestamp(expiry) if expiry else None
+        if "token" in status:
+            self.token = status["token"]
+        elif "clientCertificateData" in status and "clientKeyData" in status:
+            # Unlike the kubeconfig the ExecCredential contains PEM data, not base64
+            async with NamedTemporaryFile(delete=False) as cert_file:
+                await cert_file.write_text(status["clientCertificateData"])
+                self.client_cert_file = str(cert_file)
+            async with NamedTemporaryFile(delete=False) as key_file:
+                await key_file.write_text(status["clientKeyData"])
+                self.client_key_file = str(key_file)
+        else:
+            raise KeyError(f"Did not find credentials in {config['command']} output.")
+
+    async def _run_exec_plugin(self, config: dict) -> dict:
+        api_version = config["apiVersion"]
+        interactive_mode = config.get("interactiveMode", "IfAvailable")
+        stdin_is_tty = sys.stdin is not None and sys.stdin.isatty()
+        if interactive_mode == "Never":
+            interactive = False
+        elif interactive_mode == "IfAvailable":
+            interactive = stdin_is_tty
+        elif interactive_mode == "Always":
+            if not stdin_is_tty:
+                raise ValueError(
+                    f"Exec plugin {config['command']} requires an interactive terminal "
+                    "but stdin is not a TTY"
+                )
+            interactive = True
+        else:
+            raise ValueError(f"Unknown exec plugin interactiveMode {interactive_mode}")
+
+        exec_info = {
+            "apiVersion": api_version,
+            "kind": "ExecCredential",
+            "spec": {"interactive": interactive},
+        }
+        if config.get("provideClusterInfo"):
+            exec_info["spec"]["cluster"] = {
+                k: v
+                for k, v in {
+                    "server": self._cluster.get("server"),
+                    "tls-server-name": self._cluster.get("tls-server-name"),
+                    "insecure-skip-tls-verify": self._cluster.get(
+                        "insecure-skip-tls-verify"
+                    ),
+                    "certificate-authority-data": self._cluster.get(
+                        "certificate-authority-data"
+                    ),
+                    "proxy-url": self._cluster.get("proxy-url"),
+                    "config": self._cluster.get("extensions"),
+                }.items()
+                if v is not None
+            }
+
+        command = config["command"]
+        env = os.environ.copy()
+        env.update(**{e["name"]: e["value"] for e in config.get("env") or []})
+        env["KUBERNETES_EXEC_INFO"] = json.dumps(exec_info)
+
+        try:
+            # When interactive the plugin shares our stdin and stderr so it can prompt
+            result = await anyio.to_thread.run_sync(
+                functools.partial(
+                    subprocess.run,
+                    [command, *(config.get("args") or [])],
+                    stdin=None if interactive else subprocess.DEVNULL,
+                    stdout=subprocess.PIPE,
+                    stderr=None if interactive else subprocess.PIPE,
+                    env=env,
+                )
+            )
+        except FileNotFoundError as e:
+            message = f"Exec plugin {command} not found, is it installed and on PATH?"
+            if config.get("installHint"):
+                message += f"\n\n{config['installHint']}"
+            raise FileNotFoundError(message) from e
+        if result.returncode != 0:
+            stderr = result.stderr.decode() if result.stderr else ""
+            raise RuntimeError(
+                f"Exec plugin {command} exited with code {result.returncode}:\n{stderr}"
+            )
+        credential = json.loads(result.stdout)
+        if credential.get("apiVersion") != api_version:
+            raise ValueError(
+                f"Exec plugin {command} returned apiVersion "
+                f"{credential.get('apiVersion')}, expected {api_version}"
+            )
+        if "status" not in credential:
+            raise KeyError(f"Did not find credentials in {command} output.")
+        return credential
+
+    async def _load_service_account(self, path) -> None:
         """Load credentials from service account."""
-        self._serviceaccount = os.path.expanduser(self._serviceaccount)
-        if not os.path.isdir(self._serviceaccount):
+        path = os.path.expanduser(path)
+        if not (
+            os.path.isfile(os.path.join(path, "token"))
+            and "KUBERNETES_SERVICE_HOST" in os.environ
+            and "KUBERNETES_SERVICE_PORT" in os.environ
+        ):
             return
+        self._serviceaccount = path
         host = os.environ["KUBERNETES_SERVICE_HOST"]
         port = os.environ["KUBERNETES_SERVICE_PORT"]
+        if ":" in host:
+            host = f"[{host}]"  # IPv6
         self.server = f"https://{host}:{port}"
-        async with await anyio.open_file(
-            os.path.join(self._serviceaccount, "token")
-        ) as f:
-            self.token = await f.read()
-        self.server_ca_file = os.path.join(self._serviceaccount, "ca.crt")
+        self._token_file = os.path.join(path, "token")
+        await self.reload_token()
+        self.server_ca_file = os.path.join(path, "ca.crt")
         if self.namespace is None:
-            async with await anyio.open_file(
-                os.path.join(self._serviceaccount, "namespace")
-            ) as f:
+            async with await anyio.open_file(os.path.join(path, "namespace")) as f:
                 self.namespace = await f.read()
+
+    @property
+    def token_stale(self) -> bool:
+        """Whether the service account token should be read from disk again."""
+        if self._token_file is None:
+            return False
+        return time.monotonic() - self._token_loaded_at >= TOKEN_RELOAD_INTERVAL
+
+    def _get_client_cert_mtimes(self) -> tuple:
+        try:
+            return (
+                os.stat(self.client_cert_file).st_mtime_ns,
+                os.stat(self.client_key_file).st_mtime_ns,
+            )
+        except OSError:
+            # The files may be part way through being replaced
+            return self._client_cert_mtimes
+
+    @property
+    def client_cert_stale(self) -> bool:
+        """Whether the client certificate files have changed since they were loaded."""
+        if self._client_cert_mtimes is None:
+            return False
+        # Avoid checking the files on every request
+        now = time.monotonic()
+        if now - self._client_cert_checked_at < CLIENT_CERT_CHECK_INTERVAL:
+            return False
+        self._client_cert_checked_at = now
+        return self._get_client_cert_mtimes() != self._client_cert_mtimes
+
+    def reload_client_cert(self) -> None:
+        """Mark the client certificate files as loaded after they have changed."""
+        self._client_cert_mtimes = self._get_client_cert_mtimes()
+        self._client_cert_checked_at = time.monotonic()
+
+    async def reload_token(self) -> None:
+        """Read the service account token from disk, picking up rotated tokens."""
+        async with await anyio.open_file(self._token_file) as f:
+            self.token = await f.read()
+        self._token_loaded_at = time.monotonic()
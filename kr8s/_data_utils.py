@@ -1,7 +1,32 @@
 # SPDX-FileCopyrightText: Copyright (c) 2023, Dask Developers, NVIDIA
 # SPDX-License-Identifier: BSD 3-Clause License
 """Utilities for working with Kubernetes data structures."""
-from typing import Any, Dict, List
+import re
+from decimal import Decimal, InvalidOperation
+from typing import Any, Dict, List, NamedTuple, Optional, Union
+
+QUANTITY_SUFFIXES = {
+    "Ki": Decimal(2) ** 10,
+    "Mi": Decimal(2) ** 20,
+    "Gi": Decimal(2) ** 30,
+    "Ti": Decimal(2) ** 40,
+    "Pi": Decimal(2) ** 50,
+    "Ei": Decimal(2) ** 60,
+    "n": Decimal(10) ** -9,
+    "u": Decimal(10) ** -6,
+    "m": Decimal(10) ** -3,
+    "": Decimal(1),
+    "k": Decimal(10) ** 3,
+    "M": Decimal(10) ** 6,
+    "G": Decimal(10) ** 9,
+    "T": Decimal(10) ** 12,
+    "P": Decimal(10) ** 15,
+    "E": Decimal(10) ** 18,
+}
+QUANTITY_PATTERN = re.compile(
+    r"^([+-]?(?:[0-9]+(?:\.[0-9]*)?|\.[0-9]+)(?:[eE][+-]?[0-9]+)?)"
+    r"(Ki|Mi|Gi|Ti|Pi|Ei|n|u|m|k|M|G|T|P|E|)$"
+)
 
 
 def list_dict_unpack(
@@ -66,3 +91,60 @@ def dict_to_selector(selector_dict: Dict) -> str:
         A Kubernetes selector string.
     """
     return ",".join(f"{k}={v}" for k, v in selector_dict.items())
+
+
+class QuotaUsage(NamedTuple):
+    """How much of a resource quota is used, as parsed quantities."""
+
+    used: Decimal
+    hard: Decimal
+
+    @property
+    def utilization(self) -> Optional[Decimal]:
+        """The fraction of the quota which is used, or ``None`` for a zero quota."""
+        if not self.hard:
+            return None
+        return self.used / self.hard
+
+    def over(self, threshold: Union[float, Decimal]) -> bool:
+        """Whether the utilization has reached a threshold like ``0.8``.
+
+        A zero quota is over any threshold once anything is used.
+        """
+        if not self.hard:
+            return self.used > 0
+        return self.utilization >= Decimal(str(threshold))
+
+
+def parse_quantity(quantity: Union[str, int, float]) -> Decimal:
+    """Parse a Kubernetes quantity like ``"500m"`` or ``"128Mi"`` into a number.
+
+    Supports the binary suffixes ``Ki`` to ``Ei``, the decimal suffixes ``n`` to ``E``
+    and exponents like ``"1e3"``. CPU quantities are returned in cores and memory
+    quantities in bytes, so parsed values can be compared and added together.
+
+    Parameters
+    ----------
+    quantity : str, int or float
+        The quantity to parse.
+
+    Returns
+    -------
+    Decimal
+        The value of the quantity.
+
+    Raises
+    ------
+    ValueError
+        If the quantity is not valid.
+    """
+    if isinstance(quantity, (int, float)):
+        return Decimal(str(quantity))
+    match = QUANTITY_PATTERN.match(str(quantity).strip())
+    if not match:
+        raise ValueError(f"Invalid quantity {quantity!r}")
+    number, suffix = match.groups()
+    try:
+        return Decimal(number) * QUANTITY_SUFFIXES[suffix]
+    except InvalidOperation as e:
+        raise ValueError(f"Invalid quantity {quantity!r}") from e
@@ -1,6 +1,356 @@
-class NotFoundError(Exception):
+import json
+from typing import Optional, Type
+
+import httpx
+
+from ._retry import _parse_retry_after
+
+
+class APIError(httpx.HTTPStatusError):
+    """An error response from the Kubernetes API.
+
+    The ``Status`` object returned by the server is parsed so errors can be handled
+    without matching on messages. Subclasses are raised for common status codes and
+    reasons, and as this is a subclass of :class:`httpx.HTTPStatusError` existing
+    handlers continue to work. The original ``httpx`` error is kept as ``__cause__``.
+
+    Attributes:
+        ``status`` (dict): The ``Status`` object returned by the server.
+
+        ``code`` (int): The HTTP status code.
+
+        ``reason`` (str): The machine readable reason, e.g ``"NotFound"``.
+
+        ``details`` (dict): Extra details such as the ``name``, ``kind`` and
+        ``causes`` of the error.
+    """
+
+    default_code: Optional[int] = None
+
+    def __init__(
+        self,
+        message: str,
+        *,
+        request: httpx.Request = None,
+        response: httpx.Response = None,
+        status: dict = None,
+    ) -> None:
+        super().__init__(message, request=request, response=response)
+        if status is None and response is not None:
+            status = _parse_status(response)
+        self.status = status or {}
+
+    @property
+    def code(self) -> Optional[int]:
+        if self.status.get("code"):
+            return self.status["code"]
+        if self.response is not None:
+            return self.response.status_code
+        return self.default_code
+
+    @property
+    def reason(self) -> Optional[str]:
+        return self.status.get("reason")
+
+    @property
+    def details(self) -> dict:
+        return self.status.get("details") or {}
+
+
+class BadRequestError(APIError):
+    """The request was malformed (``400 Bad Request``)."""
+
+    default_code = 400
+
+
+class UnauthorizedError(APIError):
+    """The request could not be authenticated (``401 Unauthorized``)."""
+
+    default_code = 401
+
+
+class ForbiddenError(APIError):
+    """The authenticated user is not allowed to make the request (``403 Forbidden``)."""
+
+    default_code = 403
+
+
+class NotFoundError(APIError):
     """Unable to find the requested resource."""
 
+    default_code = 404
+
+
+class ConflictError(APIError):
+    """The request conflicts with the current state of the resource.
+
+    Raised when a write is rejected with ``409 Conflict``, for example when a server-side
+    apply conflicts with fields owned by another field manager or an update was made
+    against a stale ``resourceVersion``. The object should be read again and the change
+    retried.
+
+    Attributes:
+        ``resource_version`` (str): The ``metadata.resourceVersion`` sent with the
+        rejected request, if there was one.
+    """
+
+    default_code = 409
+
+    @property
+    def resource_version(self) -> Optional[str]:
+        try:
+            body = json.loads(self.request.content)
+        except (RuntimeError, ValueError):
+            return None
+        if not isinstance(body, dict):
+            return None
+        return body.get("metadata", {}).get("resourceVersion")
+
+
+class AlreadyExistsError(APIError):
+    """The resource being created already exists (``409`` with reason ``AlreadyExists``)."""
+
+    default_code = 409
+
+
+class ResourceVersionTooOldError(APIError):
+    """The requested resource version is no longer available (``410 Gone``).
+
+    The watch cannot be resumed, or the continue token of a paginated list has expired,
+    and the resource needs to be listed again.
+    """
+
+    default_code = 410
+
+
+class InvalidError(APIError):
+    """The resource failed validation (``422 Unprocessable Entity``).
+
+    The fields which failed validation are listed in ``details["causes"]``. This is
+    also raised for ``400`` responses with a reason of ``Invalid`` or with causes
+    describing invalid fields, and when writes with ``field_validation="Strict"``
+    are rejected for unknown or duplicate fields.
+    """
+
+    default_code = 422
+
+
+class TooManyRequestsError(APIError):
+    """The server is rate limiting requests (``429 Too Many Requests``)."""
+
+    default_code = 429
+
+
+class DisruptionBudgetError(TooManyRequestsError):
+    """An eviction was refused because it would violate a PodDisruptionBudget.
+
+    The eviction can be retried once enough replicas are available again.
+
+    Attributes:
+        ``retry_after`` (float): Seconds the server suggested waiting before retrying,
+        if it sent one.
+    """
+
+    @property
+    def retry_after(self) -> Optional[float]:
+        if self.response is not None:
+            retry_after = _parse_retry_after(self.response.headers.get("Retry-After"))
+            if retry_after is not None:
+                return retry_after
+        seconds = self.details.get("retryAfterSeconds")
+        return float(seconds) if seconds is not None else None
+
+
+class MetricsUnavailableError(APIError):
+    """Metrics aren't available, usually because metrics-server isn't installed."""
+
+
+class RequestTimeoutError(TimeoutError):
+    """A request to the Kubernetes API timed out on the client.
+
+    This is raised when no response was received within the client's ``timeout``, as
+    opposed to the server giving up on a request which raises an :class:`APIError`
+    with a ``504`` code. The original ``httpx`` error is kept as ``__cause__``.
+
+    Attributes:
+        ``request`` (httpx.Request): The request which timed out.
+
+        ``timeout`` (float): The timeout in seconds.
+    """
+
+    def __init__(
+        self, message: str, request: httpx.Request = None, timeout: float = None
+    ) -> None:
+        super().__init__(message)
+        self.request = request
+        self.timeout = timeout
+
+
+class UnsupportedContentTypeError(Exception):
+    """A response was sent in a format which can't be decoded.
+
+    Only JSON bodies can be decoded, so this is raised if the server responds with
+    protobuf or anything else when a JSON object was expected.
+
+    Attributes:
+        ``response`` (httpx.Response): The response.
+
+        ``content_type`` (str): The media type of the response body.
+    """
+
+    def __init__(
+        self, message: str, response: httpx.Response = None, content_type: str = None
+    ) -> None:
+        super().__init__(message)
+        self.response = response
+        self.content_type = content_type
+
+
+class BatchGetError(Exception):
+    """Some of the objects requested with :meth:`kr8s.Api.get_all` couldn't be fetched.
+
+    Attributes:
+        ``results`` (list): The objects which were fetched, in the same order as the
+        references, with ``None`` in place of each one which failed.
+
+        ``errors`` (list): A ``(reference, exception)`` tuple for each failure.
+    """
+
+    def __init__(self, message: str, results: list, errors: list) -> None:
+        super().__init__(message)
+        self.results = results
+        self.errors = errors
+
 
 class ConnectionClosedError(Exception):
     """A connection has been closed."""
+
+
+class ExecError(Exception):
+    """A command run with exec failed or exited with a non-zero code."""
+
+    def __init__(
+        self,
+        message: str,
+        command: list = None,
+        returncode: int = None,
+        stdout: bytes = None,
+        stderr: bytes = None,
+    ) -> None:
+        super().__init__(message)
+        self.command = command
+        self.returncode = returncode
+        self.stdout = stdout
+        self.stderr = stderr
+
+
+class RolloutError(Exception):
+    """A rollout has stalled and is not expected to complete."""
+
+
+STATUS_CODE_ERRORS = {
+    400: BadRequestError,
+    401: UnauthorizedError,
+    403: ForbiddenError,
+    404: NotFoundError,
+    409: ConflictError,
+    410: ResourceVersionTooOldError,
+    422: InvalidError,
+    429: TooManyRequestsError,
+}
+
+REASON_ERRORS = {
+    "AlreadyExists": AlreadyExistsError,
+    "Expired": ResourceVersionTooOldError,
+    "Invalid": InvalidError,
+}
+
+# Reasons of the causes of a bad request which mean fields of the object are invalid
+FIELD_CAUSE_REASONS = {
+    "FieldValueDuplicate",
+    "FieldValueForbidden",
+    "FieldValueInvalid",
+    "FieldValueNotFound",
+    "FieldValueNotSupported",
+    "FieldValueRequired",
+    "FieldValueTooLong",
+    "FieldValueTooMany",
+    "FieldValueTypeInvalid",
+}
+# Unknown and duplicate fields rejected by strict field validation have no causes
+STRICT_DECODING_ERROR = "strict decoding error"
+
+
+def _parse_status(response: httpx.Response) -> dict:
+    try:
+        status = response.json()
+    except (ValueError, httpx.ResponseNotRead):
+        return {}
+    if not isinstance(status, dict) or status.get("kind") != "Status":
+        return {}
+    return status
+
+
+def api_error_from_response(response: httpx.Response) -> APIError:
+    """Create the appropriate :class:`APIError` for an error response."""
+    status = _parse_status(response)
+    cls = REASON_ERRORS.get(status.get("reason")) or STATUS_CODE_ERRORS.get(
+        response.status_code, APIError
+    )
+    message = status.get("message") or (
+        f"{response.status_code} {response.reason_phrase} for url {response.url}"
+    )
+    causes = (status.get("details") or {}).get("causes") or []
+    if cls is BadRequestError and (
+        any(cause.get("reason") in FIELD_CAUSE_REASONS for cause in causes)
+        or (
+            response.request.url.params.get("fieldValidation") == "Strict"
+            and STRICT_DECODING_ERROR in message
+        )
+    ):
+        cls = InvalidError
+    return cls(message, request=response.request, response=response, status=status)
+
+
+def _find_error(error: BaseException, cls: Type[APIError]) -> Optional[APIError]:
+    """Find an error of this class in the chain of causes."""
+    while error is not None:
+        if isinstance(error, cls):
+            return error
+        error = error.__cause__ or error.__context__
+    return None
+
+
+def is_not_found(error: BaseException) -> bool:
+    """Whether the error, or one it was raised from, is a :class:`NotFoundError`."""
+    return _find_error(error, NotFoundError) is not None
+
+
+def is_conflict(error: BaseException) -> bool:
+    """Whether the error, or one it was raised from, is a :class:`ConflictError`."""
+    return _find_error(error, ConflictError) is not None
+
+
+def is_already_exists(error: BaseException) -> bool:
+    """Whether the error, or one it was raised from, is an :class:`AlreadyExistsError`."""
+    return _find_error(error, AlreadyExistsError) is not None
+
+
+def is_forbidden(error: BaseException) -> bool:
+    """Whether the error, or one it was raised from, is a :class:`ForbiddenError`."""
+    return _find_error(error, ForbiddenError) is not None
+
+
+def is_unauthorized(error: BaseException) -> bool:
+    """Whether the error, or one it was raised from, is an :class:`UnauthorizedError`."""
+    return _find_error(error, UnauthorizedError) is not None
+
+
+def is_invalid(error: BaseException) -> bool:
+    """Whether the error, or one it was raised from, is an :class:`InvalidError`."""
+    return _find_error(error, InvalidError) is not None
+
+
+def is_too_many_requests(error: BaseException) -> bool:
+    """Whether the error, or one it was raised from, is a :class:`TooManyRequestsError`."""
+    return _find_error(error, TooManyRequestsError) is not None
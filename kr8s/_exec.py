@@ -0,0 +1,276 @@
+# SPDX-FileCopyrightText: Copyright (c) 2023, Dask Developers, NVIDIA
+# SPDX-License-Identifier: BSD 3-Clause License
+from __future__ import annotations
+
+import inspect
+import json
+from contextlib import asynccontextmanager
+from typing import TYPE_CHECKING, Any, AsyncIterable, BinaryIO, List, Tuple, Union
+
+import aiohttp
+import anyio
+import sniffio
+
+from ._exceptions import ExecError
+
+if TYPE_CHECKING:
+    from ._objects import APIObject
+
+# Channel numbers used by the Kubernetes streaming protocols.
+STDIN_CHANNEL = 0
+STDOUT_CHANNEL = 1
+STDERR_CHANNEL = 2
+ERROR_CHANNEL = 3
+RESIZE_CHANNEL = 4
+CLOSE_CHANNEL = 255  # Only available in v5.channel.k8s.io
+
+# Offered in order of preference, v5 adds support for half-closing stdin.
+PROTOCOLS = ("v5.channel.k8s.io", "v4.channel.k8s.io")
+
+CHUNK_SIZE = 32 * 1024
+
+
+class CompletedExec:
+    """The result of a command run with :meth:`kr8s.objects.Pod.exec`.
+
+    Modelled on :class:`subprocess.CompletedProcess`.
+
+    Args:
+        ``args`` (list): The command that was run.
+
+        ``stdout`` (bytes): Captured stdout, or ``b""`` if output was not captured.
+
+        ``stderr`` (bytes): Captured stderr, or ``b""`` if output was not captured.
+        When using a TTY stderr is merged into stdout.
+
+        ``returncode`` (int): The exit status of the command.
+    """
+
+    def __init__(
+        self, args: List[str], stdout: bytes, stderr: bytes, returncode: int
+    ) -> None:
+        self.args = args
+        self.stdout = stdout
+        self.stderr = stderr
+        self.returncode = returncode
+
+    def __repr__(self):
+        return f"CompletedExec(args={self.args!r}, returncode={self.returncode!r})"
+
+    def check_returncode(self) -> None:
+        """Raise :class:`kr8s.ExecError` if the exit code is non-zero."""
+        if self.returncode != 0:
+            raise ExecError(
+                f"Command {self.args} exited with code {self.returncode}",
+                command=self.args,
+                returncode=self.returncode,
+                stdout=self.stdout,
+                stderr=self.stderr,
+            )
+
+
+class Exec:
+    """Run a command in a container and stream stdin/stdout/stderr over a websocket.
+
+    Uses the ``v5.channel.k8s.io`` protocol where the server supports it and falls back
+    to ``v4.channel.k8s.io``. With v5 reaching the end of ``stdin`` half-closes the stream
+    so the remote command sees EOF, with v4 we just stop sending data.
+
+    .. warning:
+        Currently Exec only works when using ``asyncio`` and not ``trio``.
+
+    Args:
+        ``resource`` (Pod): The Pod to run the command in.
+
+        ``command`` (list): The command to run.
+
+        ``container`` (str, optional): The container to run the command in.
+
+        ``stdin`` (bytes, str or file-like, optional): Data to send to stdin.
+
+        ``stdout`` (file-like, optional): Writer to copy stdout to as it arrives.
+
+        ``stderr`` (file-like, optional): Writer to copy stderr to as it arrives.
+
+        ``tty`` (bool, optional): Allocate a TTY. Stderr is merged into stdout.
+
+        ``resize`` (async iterable, optional): Yields ``(columns, rows)`` tuples which are
+        forwarded to the TTY as terminal resize events.
+
+        ``capture_output`` (bool, optional): Store stdout and stderr on the result.
+
+        ``subresource`` (str, optional): ``"exec"`` to run ``command``, or ``"attach"``
+        to attach to the main process of the container instead.
+    """
+
+    def __init__(
+        self,
+        resource: APIObject,
+        command: List[str],
+        container: str = None,
+        stdin: Union[str, bytes, BinaryIO] = None,
+        stdout: BinaryIO = None,
+        stderr: BinaryIO = None,
+        tty: bool = False,
+        resize: AsyncIterable[Tuple[int, int]] = None,
+        capture_output: bool = True,
+        subresource: str = "exec",
+    ) -> None:
+        if subresource not in ("exec", "attach"):
+            raise ValueError(f"Unknown subresource {subresource!r}")
+        if sniffio.current_async_library() != "asyncio":
+            raise RuntimeError(
+                "Exec only works with asyncio, "
+                "see https://github.com/kr8s-org/kr8s/issues/104"
+            )
+        self._resource = resource
+        self.args = command
+        self.container = container
+        self.tty = tty
+        self.capture_output = capture_output
+        self.subresource = subresource
+        self._stdin = stdin
+        self._stdout = stdout
+        self._stderr = stderr
+        self._resize = resize
+        self._stdout_buffer = bytearray()
+        self._stderr_buffer = bytearray()
+        self.returncode = None
+        self.protocol = None
+        self._websocket = None
+        self._done = anyio.Event()
+        self._stdin_scope = anyio.CancelScope()
+        self._resize_scope = anyio.CancelScope()
+
+    @property
+    def _params(self) -> List[Tuple[str, str]]:
+        params = []
+        if self.subresource == "exec":
+            params.extend(("command", c) for c in self.args)
+        if self.container:
+            params.append(("container", self.container))
+        if self._stdin is not None:
+            params.append(("stdin", "true"))
+        params.append(("stdout", "true"))
+        if not self.tty:
+            # When using a TTY stderr is sent on the stdout channel.
+            params.append(("stderr", "true"))
+        if self.tty:
+            params.append(("tty", "true"))
+        return params
+
+    @asynccontextmanager
+    async def run(self) -> Exec:
+        """Open the exec session and yield it, waits for the command to exit on close."""
+        async with self._resource.api.open_websocket(
+            version=self._resource.version,
+            url=f"{self._resource.endpoint}/{self._resource.name}/{self.subresource}",
+            namespace=self._resource.namespace,
+            params=self._params,
+            protocols=PROTOCOLS,
+        ) as websocket:
+            self._websocket = websocket
+            self.protocol = websocket.protocol
+            async with anyio.create_task_group() as tg:
+                tg.start_soon(self._receive)
+                if self._stdin is not None:
+                    tg.start_soon(self._send_stdin)
+                if self._resize is not None:
+                    tg.start_soon(self._send_resize)
+                yield self
+
+    async def wait(self) -> CompletedExec:
+        """Wait for the command to exit and return the result."""
+        await self._done.wait()
+        return self._result()
+
+    def _result(self) -> CompletedExec:
+        return CompletedExec(
+            args=self.args,
+            stdout=bytes(self._stdout_buffer),
+            stderr=bytes(self._stderr_buffer),
+            returncode=self.returncode,
+        )
+
+    async def resize(self, columns: int, rows: int) -> None:
+        """Send a terminal resize event to the TTY."""
+        await self._send(
+            RESIZE_CHANNEL, json.dumps({"Width": columns, "Height": rows}).encode()
+        )
+
+    async def _send(self, channel: int, data: bytes) -> None:
+        await self._websocket.send_bytes(bytes([channel]) + data)
+
+    async def _send_stdin(self) -> None:
+        with self._stdin_scope:
+            if isinstance(self._stdin, str):
+                await self._send(STDIN_CHANNEL, self._stdin.encode())
+            elif isinstance(self._stdin, bytes):
+                await self._send(STDIN_CHANNEL, self._stdin)
+            else:
+                # Prefer read1 so interactive readers return as soon as data is available
+                read = getattr(self._stdin, "read1", self._stdin.read)
+                while True:
+                    if inspect.iscoroutinefunction(read):
+                        data = await read(CHUNK_SIZE)
+                    else:
+                        data = await anyio.to_thread.run_sync(
+                            read, CHUNK_SIZE, cancellable=True
+                        )
+                    if not data:
+                        break
+                    if isinstance(data, str):
+                        data = data.encode()
+                    await self._send(STDIN_CHANNEL, data)
+            if self.protocol == "v5.channel.k8s.io":
+                # Half-close stdin so the remote process sees EOF
+                await self._send(CLOSE_CHANNEL, bytes([STDIN_CHANNEL]))
+
+    async def _send_resize(self) -> None:
+        with self._resize_scope:
+            async for columns, rows in self._resize:
+                await self.resize(columns, rows)
+
+    async def _receive(self) -> None:
+        try:
+            async for message in self._websocket:
+                if message.type != aiohttp.WSMsgType.BINARY:
+                    continue
+                if not message.data:
+                    continue
+                channel, data = message.data[0], message.data[1:]
+                if channel == STDOUT_CHANNEL:
+                    await self._write(self._stdout, self._stdout_buffer, data)
+                elif channel == STDERR_CHANNEL:
+                    await self._write(self._stderr, self._stderr_buffer, data)
+                elif channel == ERROR_CHANNEL and data:
+                    self.returncode = _returncode_from_status(json.loads(data))
+            if self.returncode is None:
+                self.returncode = 0
+        finally:
+            self._stdin_scope.cancel()
+            self._resize_scope.cancel()
+            self._done.set()
+
+    async def _write(self, writer: Any, buffer: bytearray, data: bytes) -> None:
+        if self.capture_output:
+            buffer.extend(data)
+        if writer is not None:
+            result = writer.write(data)
+            if inspect.isawaitable(result):
+                await result
+            if hasattr(writer, "flush"):
+                result = writer.flush()
+                if inspect.isawaitable(result):
+                    await result
+
+
+def _returncode_from_status(status: dict) -> int:
+    """Extract the exit code from the Status sent on the error channel."""
+    if status.get("status") == "Success":
+        return 0
+    if status.get("reason") == "NonZeroExitCode":
+        for cause in status.get("details", {}).get("causes", []):
+            if cause.get("reason") == "ExitCode":
+                return int(cause["message"])
+    raise ExecError(status.get("message", "Unknown error running command"))
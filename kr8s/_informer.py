@@ -0,0 +1,269 @@
+# SPDX-FileCopyrightText: Copyright (c) 2023, Dask Developers, NVIDIA
+# SPDX-License-Identifier: BSD 3-Clause License
+from __future__ import annotations
+
+import inspect
+from contextlib import asynccontextmanager
+from typing import TYPE_CHECKING, Callable, Dict, List, Optional, Tuple, Union
+
+import anyio
+
+from ._api import ALL
+from ._exceptions import ResourceVersionTooOldError
+from ._pager import ListPager
+from ._selectors import FieldSelector, LabelSelector
+
+if TYPE_CHECKING:
+    from ._api import Api
+    from ._objects import APIObject
+
+
+class Informer:
+    """Keep a local cache of Kubernetes resources in sync with a watch.
+
+    The resources are listed once and then watched for changes, so controllers can read
+    the current state from memory instead of listing them again. If the watch falls so
+    far behind that its resource version expires the resources are listed again, and
+    handlers are called for anything which changed in the meantime.
+
+    Handlers are registered with :meth:`add_event_handler` before the informer is
+    started. ``on_add`` and ``on_delete`` are called with the object and ``on_update``
+    with the old and new objects. Handlers can be functions or coroutine functions.
+    Every ``resync_period`` seconds ``on_update`` is called for every object in the
+    cache, with the same object as old and new, so controllers can periodically
+    reconcile everything.
+
+    Args:
+        ``api`` (Api): The API client to make requests with.
+
+        ``kind`` (str): The kind of resource to watch.
+
+        ``namespace`` (str, optional): The namespace to watch resources in.
+
+        ``label_selector`` (str, dict or LabelSelector, optional): Filter by labels.
+
+        ``field_selector`` (str, dict or FieldSelector, optional): Filter by fields.
+
+        ``resync_period`` (float, optional): Seconds between resyncs, ``None`` to never
+        resync.
+
+    Attributes:
+        ``has_synced`` (bool): Whether the initial list has been loaded into the cache.
+
+        ``relists`` (int): The number of times the resources have been listed again
+        after the watch expired.
+
+    Example:
+        This class can be used as an async context manager, which waits for the cache
+        to sync, or by running :meth:`run` in a task.
+
+        >>> informer = api.informer("pods", namespace="default")
+        >>> informer.add_event_handler(on_add=lambda pod: print("added", pod.name))
+        >>> async with informer:
+        ...     pod = informer.get("web")
+        ...     print(len(informer.list()))
+    """
+
+    def __init__(
+        self,
+        api: Api,
+        kind: str,
+        namespace: str = None,
+        label_selector: Union[str, Dict, LabelSelector] = None,
+        field_selector: Union[str, Dict, FieldSelector] = None,
+        resync_period: Optional[float] = None,
+    ) -> None:
+        self.api = api
+        self.kind = kind
+        self.namespace = namespace
+        self.label_selector = label_selector
+        self.field_selector = field_selector
+        self.resync_period = resync_period
+        self.relists = 0
+        self._store: Dict[Tuple[Optional[str], str], APIObject] = {}
+        self._handlers: List[Tuple[Callable, Callable, Callable]] = []
+        self._synced = None
+        self._run_context = None
+
+    async def __aenter__(self) -> Informer:
+        self._run_context = self._running()
+        return await self._run_context.__aenter__()
+
+    async def __aexit__(self, *args, **kwargs):
+        return await self._run_context.__aexit__(*args, **kwargs)
+
+    @asynccontextmanager
+    async def _running(self):
+        async with anyio.create_task_group() as tg:
+            tg.start_soon(self.run)
+            await self.wait_for_sync()
+            yield self
+            tg.cancel_scope.cancel()
+
+    @property
+    def _synced_event(self) -> anyio.Event:
+        # Events must be created inside the event loop
+        if self._synced is None:
+            self._synced = anyio.Event()
+        return self._synced
+
+    @property
+    def has_synced(self) -> bool:
+        return self._synced is not None and self._synced.is_set()
+
+    async def wait_for_sync(self, timeout: Optional[float] = None) -> None:
+        """Wait until the initial list has been loaded into the cache.
+
+        Args:
+            timeout: Seconds to wait for, or ``None`` to wait forever.
+
+        Raises:
+            TimeoutError: If the cache did not sync in time.
+        """
+        with anyio.fail_after(timeout):
+            await self._synced_event.wait()
+
+    def add_event_handler(
+        self,
+        on_add: Optional[Callable] = None,
+        on_update: Optional[Callable] = None,
+        on_delete: Optional[Callable] = None,
+    ) -> None:
+        """Register functions to be called when objects are added, updated or deleted.
+
+        Args:
+            on_add: Called with each object which is added.
+            on_update: Called with the old and new object each time one is modified.
+            on_delete: Called with the last known state of each object which is deleted.
+        """
+        self._handlers.append((on_add, on_update, on_delete))
+
+    def get(self, name: str, namespace: str = None) -> Optional[APIObject]:
+        """Get an object from the cache.
+
+        Args:
+            name: The name of the object.
+            namespace: The namespace of the object. Defaults to the informer's
+                namespace.
+
+        Returns:
+            The object, or ``None`` if it isn't in the cache.
+        """
+        if namespace is None:
+            namespace = self.namespace
+            if namespace in (None, ALL):
+                namespace = self.api.namespace
+        obj = self._store.get((namespace, name))
+        if obj is None:
+            # Cluster scoped objects have no namespace
+            obj = self._store.get((None, name))
+        return obj
+
+    def list(self, namespace: str = None) -> List[APIObject]:
+        """List the objects in the cache.
+
+        Args:
+            namespace: Only list objects in this namespace.
+
+        Returns:
+            The cached objects.
+        """
+        return [
+            obj
+            for (ns, _), obj in self._store.items()
+            if namespace is None or ns == namespace
+        ]
+
+    @staticmethod
+    def _key(obj: APIObject) -> Tuple[Optional[str], str]:
+        metadata = obj.raw["metadata"]
+        return metadata.get("namespace"), metadata["name"]
+
+    async def _call(self, handler: Optional[Callable], *args) -> None:
+        if handler is None:
+            return
+        result = handler(*args)
+        if inspect.isawaitable(result):
+            await result
+
+    async def _dispatch(self, event: str, *args) -> None:
+        index = ("add", "update", "delete").index(event)
+        for handlers in self._handlers:
+            await self._call(handlers[index], *args)
+
+    async def _relist(self) -> Optional[str]:
+        """List the resources, replace the cache and call handlers for any changes."""
+        pager = ListPager(
+            self.api,
+            self.kind,
+            namespace=self.namespace,
+            label_selector=self.label_selector,
+            field_selector=self.field_selector,
+            restart_on_expired=True,
+        )
+        objects = {}
+        restarts = 0
+        while not pager.done:
+            page, _ = await pager._next()
+            if pager.restarts != restarts:
+                # Pages from before the restart may be stale
+                restarts = pager.restarts
+                objects = {}
+            objects.update((self._key(obj), obj) for obj in page)
+        old, self._store = self._store, objects
+        for key, obj in objects.items():
+            if key not in old:
+                await self._dispatch("add", obj)
+            elif _resource_version(old[key]) != _resource_version(obj):
+                await self._dispatch("update", old[key], obj)
+        for key, obj in old.items():
+            if key not in objects:
+                await self._dispatch("delete", obj)
+        return pager.resource_version
+
+    async def _handle_event(self, event: str, obj: APIObject) -> None:
+        key = self._key(obj)
+        if event == "DELETED":
+            self._store.pop(key, None)
+            await self._dispatch("delete", obj)
+        elif event in ("ADDED", "MODIFIED"):
+            old = self._store.get(key)
+            self._store[key] = obj
+            if old is None:
+                await self._dispatch("add", obj)
+            else:
+                await self._dispatch("update", old, obj)
+
+    async def _resync(self) -> None:
+        while True:
+            await anyio.sleep(self.resync_period)
+            for obj in list(self._store.values()):
+                await self._dispatch("update", obj, obj)
+
+    async def run(self) -> None:
+        """List and watch the resources, updating the cache until cancelled."""
+        async with anyio.create_task_group() as tg:
+            if self.resync_period:
+                tg.start_soon(self._resync)
+            while True:
+                resource_version = await self._relist()
+                self._synced_event.set()
+                try:
+                    async for event, obj in self.api._watch(
+                        self.kind,
+                        namespace=self.namespace,
+                        label_selector=self.label_selector,
+                        field_selector=self.field_selector,
+                        since=resource_version,
+                        allow_bookmarks=True,
+                        reconnect=True,
+                    ):
+                        if event != "BOOKMARK":
+                            await self._handle_event(event, obj)
+                except ResourceVersionTooOldError:
+                    # The watch fell too far behind, list again and reconcile the cache
+                    self.relists += 1
+
+
+def _resource_version(obj: APIObject) -> Optional[str]:
+    return obj.raw["metadata"].get("resourceVersion")
@@ -65,11 +65,17 @@ def iter_over_async(agen: AsyncGenerator) -> Generator:
             return True, None
 
     with anyio.from_thread.start_blocking_portal() as portal:
-        while True:
-            done, obj = portal.call(get_next)
-            if done:
-                break
-            yield obj
+        try:
+            while True:
+                done, obj = portal.call(get_next)
+                if done:
+                    break
+                yield obj
+        finally:
+            # If the caller stops iterating early close the async generator on the
+            # portal's loop so it can release anything it holds, like open streams.
+            if hasattr(ait, "aclose"):
+                portal.call(ait.aclose)
 
 
 def sync(source: object) -> object:
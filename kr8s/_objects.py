@@ -3,11 +3,31 @@
 from __future__ import annotations
 
 import asyncio
+import datetime
+import inspect
+import io
 import json
+import os
 import pathlib
 import re
+import tarfile
+import tempfile
 import time
-from typing import Any, Dict, List, Optional, Type, Union
+import uuid
+from decimal import Decimal
+from typing import (
+    Any,
+    AsyncGenerator,
+    AsyncIterable,
+    BinaryIO,
+    Callable,
+    Dict,
+    List,
+    Optional,
+    Tuple,
+    Type,
+    Union,
+)
 
 import anyio
 import httpx
@@ -17,13 +37,45 @@ from box import Box
 
 import kr8s
 import kr8s.asyncio
-from kr8s._api import Api
-from kr8s._data_utils import dict_to_selector, dot_to_nested_dict, list_dict_unpack
-from kr8s._exceptions import NotFoundError
+from kr8s._api import ALL, Api
+from kr8s._data_utils import (
+    QuotaUsage,
+    dot_to_nested_dict,
+    list_dict_unpack,
+    parse_quantity,
+)
+from kr8s._exceptions import (
+    APIError,
+    ConflictError,
+    DisruptionBudgetError,
+    ExecError,
+    NotFoundError,
+    RolloutError,
+    TooManyRequestsError,
+)
+from kr8s._exec import CompletedExec, Exec
+from kr8s._retry import RetryPolicy
+from kr8s._selectors import FieldSelector, LabelSelector
 from kr8s.asyncio.portforward import PortForward as AsyncPortForward
 from kr8s.portforward import PortForward as SyncPortForward
 
-JSONPATH_CONDITION_EXPRESSION = r"jsonpath='{(?P<expression>.*?)}'=(?P<condition>.*)"
+# Distinguishes not passing a default from passing None
+NO_DEFAULT = object()
+JSONPATH_CONDITION_EXPRESSION = r"jsonpath='?{(?P<expression>.*?)}'?=(?P<condition>.*)"
+
+PATCH_CONTENT_TYPES = {
+    "merge": "application/merge-patch+json",
+    "strategic": "application/strategic-merge-patch+json",
+    "json": "application/json-patch+json",
+}
+# Archives for copying files are kept in memory up to this size, then spill to disk
+COPY_SPOOL_SIZE = 16 * 1024 * 1024
+COPY_CHUNK_SIZE = 1024 * 1024
+# Conflicts usually clear straight away, so retry quickly like client-go's DefaultRetry
+CONFLICT_RETRY = RetryPolicy(max_attempts=5, backoff_base=0.01, backoff_max=1.0)
+PROPAGATION_POLICIES = ("Foreground", "Background", "Orphan")
+FIELD_VALIDATION_MODES = ("Strict", "Warn", "Ignore")
+JSON_PATCH_OPERATIONS = {"add", "remove", "replace", "move", "copy", "test"}
 
 
 class APIObject:
@@ -32,6 +84,9 @@ class APIObject:
     namespaced = False
     scalable = False
     scalable_spec = "replicas"
+    scalable_subresource = False
+    restartable = False
+    status_subresource = False
     _asyncio = True
 
     def __init__(self, resource: dict, namespace: str = None, api: Api = None) -> None:
@@ -85,6 +140,43 @@ class APIObject:
     def raw(self, value: Any) -> None:
         self._raw = value
 
+    def _manifest(self, strip_managed_fields: bool = False) -> dict:
+        # Round trip through JSON to get plain dicts while keeping the API's key order
+        manifest = json.loads(json.dumps(self.raw))
+        if strip_managed_fields:
+            manifest.get("metadata", {}).pop("managedFields", None)
+        return manifest
+
+    def to_yaml(self, strip_managed_fields: bool = False) -> str:
+        """Serialize the object to YAML.
+
+        Keys are kept in the order returned by the Kubernetes API so the output is
+        stable and can be diffed.
+
+        Args:
+            strip_managed_fields: Remove ``metadata.managedFields`` from the output.
+
+        Returns:
+            The object as a YAML document.
+        """
+        return yaml.safe_dump(
+            self._manifest(strip_managed_fields),
+            sort_keys=False,
+            default_flow_style=False,
+        )
+
+    def to_json(self, strip_managed_fields: bool = False, indent: int = 4) -> str:
+        """Serialize the object to JSON.
+
+        Args:
+            strip_managed_fields: Remove ``metadata.managedFields`` from the output.
+            indent: The number of spaces to indent by.
+
+        Returns:
+            The object as a JSON document.
+        """
+        return json.dumps(self._manifest(strip_managed_fields), indent=indent)
+
     @property
     def name(self) -> str:
         """Name of the Kubernetes resource."""
@@ -128,6 +220,226 @@ class APIObject:
         except KeyError:
             return Box({})
 
+    def jsonpath(self, expression: str, default: Any = NO_DEFAULT) -> Any:
+        """Get a value from the object with a JSONPath expression.
+
+        Expressions use the same syntax as ``kubectl get -o jsonpath``, with or without
+        the surrounding braces, including filters like
+        ``{.status.conditions[?(@.type=="Ready")].status}`` and indexes like
+        ``{.spec.containers[0].image}``.
+
+        Args:
+            expression: The JSONPath expression.
+            default: Returned if nothing matches, otherwise a ``KeyError`` is raised.
+
+        Returns:
+            The matching value, or a list of values if the expression matches more
+            than one.
+
+        Raises:
+            KeyError: If nothing matches and there is no default.
+            ValueError: If the expression is invalid.
+
+        Example:
+            >>> pod.jsonpath('{.status.conditions[?(@.type=="Ready")].status}')
+            'True'
+            >>> pod.jsonpath(".metadata.labels.app", default=None)
+        """
+        values = self.jsonpath_all(expression)
+        if not values:
+            if default is NO_DEFAULT:
+                raise KeyError(
+                    f"JSONPath {expression} not found in {self.kind} {self.name}"
+                )
+            return default
+        return values[0] if len(values) == 1 else values
+
+    def jsonpath_all(self, expression: str) -> List[Any]:
+        """Get every value in the object matching a JSONPath expression.
+
+        Args:
+            expression: The JSONPath expression, see :meth:`jsonpath`.
+
+        Returns:
+            A list of the matching values, which is empty if nothing matches.
+
+        Raises:
+            ValueError: If the expression is invalid.
+        """
+        return _jsonpath_findall(expression, self.raw)
+
+    def jsonpath_str(self, expression: str, default: Any = NO_DEFAULT) -> str:
+        """Get a single string from the object with a JSONPath expression.
+
+        Args:
+            expression: The JSONPath expression, see :meth:`jsonpath`.
+            default: Returned if nothing matches, otherwise a ``KeyError`` is raised.
+
+        Raises:
+            KeyError: If nothing matches and there is no default.
+            TypeError: If the expression matches more than one value or the value
+                isn't a string.
+            ValueError: If the expression is invalid.
+        """
+        return self._jsonpath_typed(expression, str, default)
+
+    def jsonpath_int(self, expression: str, default: Any = NO_DEFAULT) -> int:
+        """Get a single integer from the object with a JSONPath expression.
+
+        Args:
+            expression: The JSONPath expression, see :meth:`jsonpath`.
+            default: Returned if nothing matches, otherwise a ``KeyError`` is raised.
+
+        Raises:
+            KeyError: If nothing matches and there is no default.
+            TypeError: If the expression matches more than one value or the value
+                isn't an integer.
+            ValueError: If the expression is invalid.
+        """
+        return self._jsonpath_typed(expression, int, default)
+
+    def jsonpath_bool(self, expression: str, default: Any = NO_DEFAULT) -> bool:
+        """Get a single boolean from the object with a JSONPath expression.
+
+        Conditions store their status as the strings ``"True"`` and ``"False"``, use
+        :meth:`jsonpath_str` to read them.
+
+        Args:
+            expression: The JSONPath expression, see :meth:`jsonpath`.
+            default: Returned if nothing matches, otherwise a ``KeyError`` is raised.
+
+        Raises:
+            KeyError: If nothing matches and there is no default.
+            TypeError: If the expression matches more than one value or the value
+                isn't a boolean.
+            ValueError: If the expression is invalid.
+        """
+        return self._jsonpath_typed(expression, bool, default)
+
+    def _jsonpath_typed(self, expression: str, cls: type, default: Any) -> Any:
+        """Get a single value of a type with a JSONPath expression."""
+        values = self.jsonpath_all(expression)
+        if not values:
+            if default is NO_DEFAULT:
+                raise KeyError(
+                    f"JSONPath {expression} not found in {self.kind} {self.name}"
+                )
+            return default
+        if len(values) > 1:
+            raise TypeError(
+                f"JSONPath {expression} matched {len(values)} values in {self.kind} "
+                f"{self.name}, expected a single {cls.__name__}"
+            )
+        [value] = values
+        # Booleans are integers in Python but not in JSON
+        if not isinstance(value, cls) or (cls is int and isinstance(value, bool)):
+            raise TypeError(
+                f"JSONPath {expression} in {self.kind} {self.name} is "
+                f"{type(value).__name__} {value!r}, expected {cls.__name__}"
+            )
+        return value
+
+    @property
+    def conditions(self) -> List[Box]:
+        """Status conditions of the Kubernetes resource."""
+        status = self.raw.get("status") or {}
+        return [Box(condition) for condition in status.get("conditions") or []]
+
+    def _condition_status(self, condition_type: str) -> Optional[str]:
+        """The status of a condition, or ``None`` if there is no such condition."""
+        for condition in self.conditions:
+            if condition.get("type") == condition_type:
+                return condition.get("status")
+        return None
+
+    @property
+    def owners(self) -> List[Box]:
+        """Owner references of the Kubernetes resource."""
+        return [Box(ref) for ref in self.raw["metadata"].get("ownerReferences", [])]
+
+    @property
+    def controller_owner(self) -> Optional[Box]:
+        """The owner reference of the controller managing this resource, if any."""
+        return next((ref for ref in self.owners if ref.get("controller")), None)
+
+    def is_owned_by(self, owner: APIObject) -> bool:
+        """Check if this object has an owner reference to another object."""
+        uid = owner.raw["metadata"].get("uid")
+        return uid is not None and any(ref.get("uid") == uid for ref in self.owners)
+
+    def set_owner(
+        self,
+        owner: APIObject,
+        controller: bool = False,
+        block_owner_deletion: bool = False,
+    ) -> None:
+        """Add a reference to an owner so this object is garbage collected with it.
+
+        Only the local object is changed, call :meth:`create`, :meth:`update` or
+        :meth:`apply` to write it to Kubernetes. If there is already a reference to the
+        owner it is replaced.
+
+        Args:
+            owner: The owning object, which must exist in Kubernetes so it has a
+                ``metadata.uid``.
+            controller: Mark the owner as the controller managing this object. An
+                object can only have one controller.
+            block_owner_deletion: Stop the owner being deleted with foreground
+                deletion until this object has been deleted.
+
+        Raises:
+            ValueError: If the owner has no uid, is in a different namespace or this
+                object already has a different controller.
+
+        Example:
+            >>> config_map.set_owner(deployment, controller=True)
+            >>> await config_map.create()
+        """
+        uid = owner.raw["metadata"].get("uid")
+        if not uid:
+            raise ValueError(
+                f"{owner.kind} {owner.name} has no uid, create or refresh it first"
+            )
+        if owner.namespaced:
+            namespace = owner.raw["metadata"].get("namespace")
+            if not self.namespaced:
+                raise ValueError(
+                    f"Cluster scoped {self.kind} {self.name} can't be owned by "
+                    f"namespaced {owner.kind} {owner.name}"
+                )
+            own_namespace = self.raw["metadata"].get("namespace")
+            if own_namespace is None and self.api is not None:
+                own_namespace = self.api.namespace
+            if namespace and own_namespace and namespace != own_namespace:
+                raise ValueError(
+                    f"{owner.kind} {owner.name} is in namespace {namespace}, "
+                    f"owners must be in the same namespace as {self.kind} {self.name}"
+                )
+        references = [
+            ref
+            for ref in self.raw["metadata"].get("ownerReferences", [])
+            if ref.get("uid") != uid
+        ]
+        if controller:
+            current = next((ref for ref in references if ref.get("controller")), None)
+            if current is not None:
+                raise ValueError(
+                    f"{self.kind} {self.name} is already controlled by "
+                    f"{current['kind']} {current['name']}"
+                )
+        reference = {
+            "apiVersion": owner.version,
+            "kind": owner.kind,
+            "name": owner.name,
+            "uid": uid,
+        }
+        if controller:
+            reference["controller"] = True
+        if block_owner_deletion:
+            reference["blockOwnerDeletion"] = True
+        references.append(reference)
+        self.raw["metadata"]["ownerReferences"] = references
+
     @property
     def replicas(self) -> int:
         """Replicas of the Kubernetes resource."""
@@ -145,8 +457,8 @@ class APIObject:
         name: str = None,
         namespace: str = None,
         api: Api = None,
-        label_selector: Union[str, Dict[str, str]] = None,
-        field_selector: Union[str, Dict[str, str]] = None,
+        label_selector: Union[str, Dict[str, str], LabelSelector] = None,
+        field_selector: Union[str, Dict[str, str], FieldSelector] = None,
         timeout: int = 2,
         **kwargs,
     ) -> APIObject:
@@ -205,35 +517,269 @@ class APIObject:
             raise NotFoundError(f"Object {self.name} does not exist")
         return False
 
-    async def create(self) -> None:
+    async def create(
+        self, dry_run: bool = False, field_validation: str = None
+    ) -> APIObject:
+        """Create this object in Kubernetes.
+
+        Args:
+            dry_run: Validate the request on the server, including admission webhooks,
+                without persisting it.
+            field_validation: How the server handles unknown or duplicate fields, one
+                of ``"Strict"``, ``"Warn"`` or ``"Ignore"``.
+
+        Returns:
+            This object updated with the server's result. If ``dry_run`` is set this
+            object is left unchanged and the would-be result is returned as a new object.
+        """
+        return await self._create(dry_run=dry_run, field_validation=field_validation)
+
+    async def _create(
+        self, dry_run: bool = False, field_validation: str = None
+    ) -> APIObject:
         """Create this object in Kubernetes."""
         async with self.api.call_api(
             "POST",
             version=self.version,
             url=self.endpoint,
             namespace=self.namespace,
+            params=_write_params(dry_run, field_validation),
             data=json.dumps(self.raw),
         ) as resp:
-            self.raw = resp.json()
+            return self._dry_run_result(resp.json(), dry_run)
+
+    async def update(
+        self, dry_run: bool = False, field_validation: str = None
+    ) -> APIObject:
+        """Replace this object in Kubernetes with its current local state.
+
+        The ``metadata.resourceVersion`` of this object is sent with the request so the
+        update fails if the object has been modified since it was last read.
+
+        Args:
+            dry_run: Validate the request on the server, including admission webhooks,
+                without persisting it.
+            field_validation: How the server handles unknown or duplicate fields, one
+                of ``"Strict"``, ``"Warn"`` or ``"Ignore"``.
 
-    async def delete(self, propagation_policy: str = None) -> None:
+        Returns:
+            This object updated with the server's result. If ``dry_run`` is set this
+            object is left unchanged and the would-be result is returned as a new object.
+
+        Raises:
+            ConflictError: If the object has been modified since it was last read.
+        """
+        return await self._update(dry_run=dry_run, field_validation=field_validation)
+
+    async def _update(
+        self, dry_run: bool = False, field_validation: str = None
+    ) -> APIObject:
+        """Replace this object in Kubernetes."""
+        async with self.api.call_api(
+            "PUT",
+            version=self.version,
+            url=f"{self.endpoint}/{self.name}",
+            namespace=self.namespace,
+            params=_write_params(dry_run, field_validation),
+            data=json.dumps(self.raw),
+        ) as resp:
+            return self._dry_run_result(resp.json(), dry_run)
+
+    async def update_with_retry(
+        self,
+        mutate: Callable[[APIObject], Any],
+        retry: RetryPolicy = None,
+        dry_run: bool = False,
+        field_validation: str = None,
+    ) -> APIObject:
+        """Modify this object and update it in Kubernetes, retrying on conflicts.
+
+        The latest version of the object is read, ``mutate`` is called with this object
+        to change ``raw`` in place and then the object is updated. If another writer
+        updated the object in the meantime the update is rejected with a conflict, so
+        the object is read again and ``mutate`` is called again with the new state.
+
+        Args:
+            mutate: A function or coroutine function which changes the object.
+            retry: A :class:`kr8s.RetryPolicy` controlling the number of attempts and
+                the delay between them, by default five attempts are made.
+            dry_run: Validate the request on the server without persisting it.
+            field_validation: How the server handles unknown or duplicate fields, one
+                of ``"Strict"``, ``"Warn"`` or ``"Ignore"``.
+
+        Returns:
+            This object updated with the server's result.
+
+        Raises:
+            ConflictError: If the update still conflicts after the last attempt.
+
+        Example:
+            >>> def scale(deployment):
+            ...     deployment.raw["spec"]["replicas"] += 1
+            >>> await deployment.update_with_retry(scale)
+        """
+        return await self._update_with_retry(
+            mutate, retry=retry, dry_run=dry_run, field_validation=field_validation
+        )
+
+    async def _update_with_retry(
+        self,
+        mutate: Callable[[APIObject], Any],
+        retry: RetryPolicy = None,
+        dry_run: bool = False,
+        field_validation: str = None,
+    ) -> APIObject:
+        retry = retry or CONFLICT_RETRY
+        for attempt in range(1, retry.max_attempts + 1):
+            await self._refresh()
+            resource_version = self.raw["metadata"]["resourceVersion"]
+            result = mutate(self)
+            if inspect.isawaitable(result):
+                await result
+            # Always update against the version that was read so the server can
+            # detect concurrent writes
+            self.raw["metadata"]["resourceVersion"] = resource_version
+            try:
+                return await self._update(
+                    dry_run=dry_run, field_validation=field_validation
+                )
+            except ConflictError:
+                if attempt >= retry.max_attempts:
+                    raise
+                await anyio.sleep(retry.delay(attempt))
+
+    async def update_status(self, dry_run: bool = False) -> APIObject:
+        """Replace the status of this object in Kubernetes with its local state.
+
+        Only the ``status`` is written, changes to the rest of the object are ignored
+        by the server. Likewise :meth:`update` and :meth:`patch` ignore the ``status``
+        of resources which have a status subresource.
+
+        Args:
+            dry_run: Validate the request on the server without persisting it.
+
+        Returns:
+            This object updated with the server's result. If ``dry_run`` is set this
+            object is left unchanged and the would-be result is returned as a new object.
+
+        Raises:
+            ConflictError: If the object has been modified since it was last read.
+        """
+        return await self._update_status(dry_run=dry_run)
+
+    async def _update_status(self, dry_run: bool = False) -> APIObject:
+        """Replace the status of this object in Kubernetes."""
+        self._ensure_status_subresource()
+        async with self.api.call_api(
+            "PUT",
+            version=self.version,
+            url=f"{self.endpoint}/{self.name}/status",
+            namespace=self.namespace,
+            params={"dryRun": "All"} if dry_run else None,
+            data=json.dumps(self.raw),
+        ) as resp:
+            return self._dry_run_result(resp.json(), dry_run)
+
+    async def patch_status(
+        self,
+        patch: Union[Dict, List[Dict]],
+        *,
+        type: str = "merge",
+        dry_run: bool = False,
+    ) -> APIObject:
+        """Patch the status of this object in Kubernetes.
+
+        Args:
+            patch: The patch to apply to the status subresource, see :meth:`patch`.
+            type: The patch strategy, one of ``"merge"``, ``"strategic"`` or ``"json"``.
+            dry_run: Validate the request on the server without persisting it.
+
+        Returns:
+            This object updated with the server's result. If ``dry_run`` is set this
+            object is left unchanged and the would-be result is returned as a new object.
+        """
+        return await self._patch_status(patch, type=type, dry_run=dry_run)
+
+    async def _patch_status(
+        self,
+        patch: Union[Dict, List[Dict]],
+        *,
+        type: str = "merge",
+        dry_run: bool = False,
+    ) -> APIObject:
+        """Patch the status of this object in Kubernetes."""
+        self._ensure_status_subresource()
+        return await self._patch(patch, subresource="status", type=type, dry_run=dry_run)
+
+    def _ensure_status_subresource(self) -> None:
+        if not self.status_subresource:
+            raise NotImplementedError(f"{self.kind} does not have a status subresource")
+
+    def _dry_run_result(self, result: dict, dry_run: bool) -> APIObject:
+        """Store the result of a write, or return it as a new object for a dry run."""
+        if dry_run:
+            return self.__class__(result, api=self.api)
+        self.raw = result
+        return self
+
+    async def delete(
+        self,
+        propagation_policy: str = None,
+        grace_period: int = None,
+        preconditions: dict = None,
+    ) -> None:
+        """Delete this object from Kubernetes.
+
+        Args:
+            propagation_policy: How dependents are garbage collected, one of
+                ``"Foreground"``, ``"Background"`` or ``"Orphan"``. With
+                ``"Foreground"`` the object remains until its dependents are deleted,
+                which can be waited on with :meth:`wait_deleted`.
+            grace_period: Seconds the object has to terminate, ``0`` deletes it
+                immediately.
+            preconditions: A dict with the ``uid`` and/or ``resourceVersion`` the object
+                must have for it to be deleted, e.g ``{"uid": obj.metadata.uid}`` to
+                avoid deleting an object which has been recreated.
+
+        Raises:
+            ConflictError: If the preconditions don't match the object.
+        """
+        await self._delete(
+            propagation_policy=propagation_policy,
+            grace_period=grace_period,
+            preconditions=preconditions,
+        )
+
+    async def _delete(
+        self,
+        propagation_policy: str = None,
+        grace_period: int = None,
+        preconditions: dict = None,
+    ) -> None:
         """Delete this object from Kubernetes."""
         data = {}
         if propagation_policy:
+            if propagation_policy not in PROPAGATION_POLICIES:
+                raise ValueError(
+                    f"Unknown propagation policy {propagation_policy!r}, "
+                    f"must be one of {', '.join(PROPAGATION_POLICIES)}"
+                )
             data["propagationPolicy"] = propagation_policy
-        try:
-            async with self.api.call_api(
-                "DELETE",
-                version=self.version,
-                url=f"{self.endpoint}/{self.name}",
-                namespace=self.namespace,
-                data=json.dumps(data),
-            ) as resp:
-                self.raw = resp.json()
-        except httpx.HTTPStatusError as e:
-            if e.response.status_code == 404:
-                raise NotFoundError(f"Object {self.name} does not exist") from e
-            raise e
+        if grace_period is not None:
+            data["gracePeriodSeconds"] = grace_period
+        if preconditions:
+            unknown = set(preconditions) - {"uid", "resourceVersion"}
+            if unknown:
+                raise ValueError(f"Unknown preconditions {', '.join(sorted(unknown))}")
+            data["preconditions"] = preconditions
+        async with self.api.call_api(
+            "DELETE",
+            version=self.version,
+            url=f"{self.endpoint}/{self.name}",
+            namespace=self.namespace,
+            data=json.dumps(data),
+        ) as resp:
+            self.raw = resp.json()
 
     async def refresh(self) -> None:
         """Refresh this object from Kubernetes."""
@@ -241,47 +787,284 @@ class APIObject:
 
     async def _refresh(self) -> None:
         """Refresh this object from Kubernetes."""
+        async with self.api.call_api(
+            "GET",
+            version=self.version,
+            url=f"{self.endpoint}/{self.name}",
+            namespace=self.namespace,
+        ) as resp:
+            self.raw = resp.json()
+
+    async def _deleted_or_replaced(self, uid: Optional[str]) -> bool:
+        """Check if this object was deleted or replaced by a new one with its name."""
         try:
-            async with self.api.call_api(
-                "GET",
-                version=self.version,
-                url=f"{self.endpoint}/{self.name}",
-                namespace=self.namespace,
-            ) as resp:
-                self.raw = resp.json()
-        except httpx.HTTPStatusError as e:
-            if e.response.status_code == 404:
-                raise NotFoundError(f"Object {self.name} does not exist") from e
-            raise e
+            await self._refresh()
+        except NotFoundError:
+            return True
+        return uid is not None and self.metadata.get("uid") != uid
 
-    async def patch(self, patch, *, subresource=None) -> None:
-        """Patch this object in Kubernetes."""
-        await self._patch(patch, subresource=subresource)
+    async def patch(
+        self,
+        patch: Union[Dict, List[Dict]],
+        *,
+        subresource=None,
+        type: str = "merge",
+        dry_run: bool = False,
+        field_validation: str = None,
+    ) -> APIObject:
+        """Patch this object in Kubernetes.
+
+        Args:
+            patch: The patch to apply, a dict for merge patches or a list of operations
+                for JSON patches.
+            subresource: The subresource to patch, e.g ``"status"``.
+            type: The patch strategy, one of ``"merge"`` for a JSON merge patch
+                (RFC 7386), ``"strategic"`` for a strategic merge patch or ``"json"`` for
+                a JSON patch (RFC 6902). Strategic merge patches are only supported by
+                built-in resources, not custom resources.
+            dry_run: Validate the request on the server, including admission webhooks,
+                without persisting it.
+            field_validation: How the server handles unknown or duplicate fields, one
+                of ``"Strict"``, ``"Warn"`` or ``"Ignore"``.
+
+        Returns:
+            This object updated with the server's result. If ``dry_run`` is set this
+            object is left unchanged and the would-be result is returned as a new object.
+        """
+        return await self._patch(
+            patch,
+            subresource=subresource,
+            type=type,
+            dry_run=dry_run,
+            field_validation=field_validation,
+        )
 
-    async def _patch(self, patch: Dict, *, subresource=None) -> None:
+    async def _patch(
+        self,
+        patch: Union[Dict, List[Dict]],
+        *,
+        subresource=None,
+        type: str = "merge",
+        dry_run: bool = False,
+        field_validation: str = None,
+    ) -> APIObject:
         """Patch this object in Kubernetes."""
+        if type not in PATCH_CONTENT_TYPES:
+            raise ValueError(
+                f"Unknown patch type {type!r}, "
+                f"must be one of {', '.join(PATCH_CONTENT_TYPES)}"
+            )
+        if type == "json":
+            _validate_json_patch(patch)
         url = f"{self.endpoint}/{self.name}"
         if subresource:
             url = f"{url}/{subresource}"
+        try:
+            async with self.api.call_api(
+                "PATCH",
+                version=self.version,
+                url=url,
+                namespace=self.namespace,
+                params=_write_params(dry_run, field_validation),
+                data=json.dumps(patch),
+                headers={"Content-Type": PATCH_CONTENT_TYPES[type]},
+            ) as resp:
+                return self._dry_run_result(resp.json(), dry_run)
+        except APIError as e:
+            if type == "strategic" and e.code == 415:
+                raise ValueError(
+                    f"{self.kind} does not support strategic merge patches, they are "
+                    "only supported by built-in resources. Use type='merge' instead."
+                ) from e
+            raise
+
+    async def apply(
+        self,
+        field_manager: str = "kr8s",
+        force: bool = False,
+        dry_run: bool = False,
+        field_validation: str = None,
+    ) -> APIObject:
+        """Server-side apply this object in Kubernetes.
+
+        Creates the object if it doesn't exist, otherwise the server merges the fields in this
+        object into the existing one and records ``field_manager`` as their owner.
+
+        Args:
+            field_manager: The name of the manager that owns the applied fields.
+            force: Take ownership of fields that are owned by other field managers
+                instead of failing with a conflict.
+            dry_run: Validate the request on the server without persisting it.
+            field_validation: How the server handles unknown or duplicate fields, one
+                of ``"Strict"``, ``"Warn"`` or ``"Ignore"``.
+
+        Returns:
+            This object updated with the server's merged result. If ``dry_run`` is set this
+            object is left unchanged and the would-be result is returned as a new object.
+
+        Raises:
+            ConflictError: If applied fields are owned by another field manager and
+                ``force`` is not set.
+        """
+        return await self._apply(
+            field_manager=field_manager,
+            force=force,
+            dry_run=dry_run,
+            field_validation=field_validation,
+        )
+
+    async def _apply(
+        self,
+        field_manager: str = "kr8s",
+        force: bool = False,
+        dry_run: bool = False,
+        field_validation: str = None,
+    ) -> APIObject:
+        """Server-side apply this object in Kubernetes."""
+        body = {"apiVersion": self.version, "kind": self.kind, **self.raw}
+        body["metadata"] = {
+            k: v for k, v in body["metadata"].items() if k != "managedFields"
+        }
+        params = {"fieldManager": field_manager}
+        if force:
+            params["force"] = "true"
+        params.update(_write_params(dry_run, field_validation) or {})
         async with self.api.call_api(
             "PATCH",
             version=self.version,
-            url=url,
+            url=f"{self.endpoint}/{self.name}",
             namespace=self.namespace,
-            data=json.dumps(patch),
-            headers={"Content-Type": "application/merge-patch+json"},
+            params=params,
+            data=json.dumps(body),
+            headers={"Content-Type": "application/apply-patch+yaml"},
         ) as resp:
-            self.raw = resp.json()
+            result = resp.json()
+        return self._dry_run_result(result, dry_run)
+
+    async def scale(self, replicas: int = None) -> Optional[dict]:
+        """Scale this object in Kubernetes.
 
-    async def scale(self, replicas: int = None) -> None:
-        """Scale this object in Kubernetes."""
+        Resources with a ``scale`` subresource, like Deployments and StatefulSets, are
+        scaled through it so RBAC rules on ``<resource>/scale`` apply and fields managed
+        by autoscalers are left alone. Otherwise the replicas field is patched directly.
+
+        Args:
+            replicas: The number of replicas to scale to.
+
+        Returns:
+            The ``autoscaling/v1`` Scale object returned by the server, including the
+            observed replicas in its status, or ``None`` if there is no scale
+            subresource.
+        """
         if not self.scalable:
             raise NotImplementedError(f"{self.kind} is not scalable")
         await self._exists(ensure=True)
-        await self._patch({"spec": dot_to_nested_dict(self.scalable_spec, replicas)})
+        scale = None
+        if self.scalable_subresource:
+            async with self.api.call_api(
+                "PATCH",
+                version=self.version,
+                url=f"{self.endpoint}/{self.name}/scale",
+                namespace=self.namespace,
+                data=json.dumps({"spec": {"replicas": replicas}}),
+                headers={"Content-Type": "application/merge-patch+json"},
+            ) as resp:
+                scale = resp.json()
+        else:
+            await self._patch(
+                {"spec": dot_to_nested_dict(self.scalable_spec, replicas)}
+            )
         while self.replicas != replicas:
             await self._refresh()
             await asyncio.sleep(0.1)
+        return scale
+
+    async def current_scale(self) -> int:
+        """Get the desired number of replicas from the ``scale`` subresource."""
+        return (await self._get_scale())["spec"].get("replicas", 0)
+
+    async def _get_scale(self) -> dict:
+        """Get the ``autoscaling/v1`` Scale object for this resource."""
+        if not self.scalable_subresource:
+            raise NotImplementedError(f"{self.kind} does not have a scale subresource")
+        async with self.api.call_api(
+            "GET",
+            version=self.version,
+            url=f"{self.endpoint}/{self.name}/scale",
+            namespace=self.namespace,
+        ) as resp:
+            return resp.json()
+
+    async def rollout_restart(self) -> None:
+        """Restart the pods of this workload, like ``kubectl rollout restart``.
+
+        Sets the ``kubectl.kubernetes.io/restartedAt`` annotation on the pod template
+        to the current time, which causes the controller to roll out new pods.
+        """
+        if not self.restartable:
+            raise NotImplementedError(f"{self.kind} does not support rollout restart")
+        restarted_at = datetime.datetime.now(datetime.timezone.utc).strftime(
+            "%Y-%m-%dT%H:%M:%SZ"
+        )
+        await self._patch(
+            {
+                "spec": {
+                    "template": {
+                        "metadata": {
+                            "annotations": {
+                                "kubectl.kubernetes.io/restartedAt": restarted_at
+                            }
+                        }
+                    }
+                }
+            }
+        )
+
+    async def rollout_status(
+        self, timeout: int = None, poll_interval: float = 1
+    ) -> AsyncGenerator[dict]:
+        """Follow the rollout of this workload, like ``kubectl rollout status``.
+
+        Yields the progress of the rollout each time it changes, a dict with the
+        ``desired``, ``updated``, ``ready`` and ``available`` replicas and a ``message``
+        describing what the rollout is waiting for. The last dict yielded has ``done``
+        set to ``True``.
+
+        Args:
+            timeout: Seconds to wait for the rollout. Waits forever by default.
+            poll_interval: Seconds between checks of the rollout progress.
+
+        Raises:
+            RolloutError: If the rollout has stalled, e.g the Deployment exceeded its
+                ``progressDeadlineSeconds``.
+            TimeoutError: If the rollout did not complete in time.
+        """
+        if not self.restartable:
+            raise NotImplementedError(f"{self.kind} does not support rollout status")
+        progress = None
+        with anyio.move_on_after(timeout):
+            while True:
+                await self._refresh()
+                latest = self._rollout_progress()
+                if latest != progress:
+                    progress = latest
+                    yield progress
+                if progress["done"]:
+                    return
+                await anyio.sleep(poll_interval)
+        message = progress["message"] if progress else "unknown"
+        raise TimeoutError(
+            f"Timed out after {timeout}s waiting for rollout of {self.kind} "
+            f"{self.name} to complete: {message}"
+        )
+
+    def _rollout_progress(self) -> dict:
+        """Describe the progress of the current rollout from the object status."""
+        raise NotImplementedError(f"{self.kind} does not support rollout status")
+
+    def _rollout_waiting_for_generation(self) -> bool:
+        status = self.raw.get("status", {})
+        return status.get("observedGeneration", 0) < self.metadata.get("generation", 0)
 
     async def _watch(self):
         """Watch this object in Kubernetes."""
@@ -300,10 +1083,57 @@ class APIObject:
         async for event, obj in self._watch():
             yield event, obj
 
+    async def events(self) -> List[Event]:
+        """Get the events about this object, like ``kubectl describe`` shows.
+
+        Returns:
+            The events, oldest first.
+        """
+        events = await self.api._get(
+            "events",
+            namespace=self._events_namespace(),
+            field_selector=self._events_field_selector(),
+        )
+        return sorted(events, key=lambda event: event.last_timestamp)
+
+    async def watch_events(self) -> AsyncGenerator[Event]:
+        """Watch the events about this object as they happen.
+
+        Existing events are yielded first, followed by any new or updated events.
+        """
+        async for event_type, event in self.api._watch(
+            "events",
+            namespace=self._events_namespace(),
+            field_selector=self._events_field_selector(),
+        ):
+            if event_type in ("ADDED", "MODIFIED"):
+                yield event
+
+    def _events_namespace(self) -> str:
+        # Events about cluster scoped objects like Nodes can be in any namespace
+        return self.namespace if self.namespaced else ALL
+
+    def _events_field_selector(self) -> FieldSelector:
+        selector = FieldSelector().equals("involvedObject.name", self.name)
+        selector = selector.equals("involvedObject.kind", self.kind)
+        if self.namespaced:
+            selector = selector.equals("involvedObject.namespace", self.namespace)
+            # The kubelet uses the node name as the UID in events about nodes, so the
+            # UID is only used to tell apart namespaced objects with the same name
+            if self.metadata.get("uid"):
+                selector = selector.equals("involvedObject.uid", self.metadata.uid)
+        return selector
+
     async def _test_conditions(self, conditions: list) -> bool:
         """Test if conditions are met."""
         for condition in conditions:
-            if condition.startswith("condition"):
+            if callable(condition):
+                result = condition(self)
+                if inspect.isawaitable(result):
+                    result = await result
+                if not result:
+                    return False
+            elif condition.startswith("condition"):
                 condition = "=".join(condition.split("=")[1:])
                 if "=" in condition:
                     field, value = condition.split("=")
@@ -323,31 +1153,160 @@ class APIObject:
                     return False
             elif condition.startswith("jsonpath"):
                 matches = re.search(JSONPATH_CONDITION_EXPRESSION, condition)
+                if not matches:
+                    raise ValueError(f"Unable to parse jsonpath condition {condition}")
                 expression = matches.group("expression")
                 condition = matches.group("condition")
-                [value] = jsonpath.findall(expression, self._raw)
-                if value != condition:
+                values = _jsonpath_findall(expression, self._raw)
+                if len(values) != 1 or str(values[0]) != condition:
                     return False
             else:
                 raise ValueError(f"Unknown condition type {condition}")
         return True
 
-    async def wait(self, conditions: Union[List[str], str], timeout: int = None):
-        """Wait for conditions to be met."""
-        if isinstance(conditions, str):
-            conditions = [conditions]
+    def _describe_state(self) -> str:
+        """Summarise the status of this object for error messages."""
+        status = self.raw.get("status") or {}
+        parts = []
+        if "phase" in status:
+            parts.append(f"phase={status['phase']}")
+        if status.get("conditions"):
+            conditions = ", ".join(
+                f"{c.get('type')}={c.get('status')}" for c in status["conditions"]
+            )
+            parts.append(f"conditions=[{conditions}]")
+        return " ".join(parts) or "no status"
+
+    async def wait(
+        self,
+        conditions: Union[List[Union[str, Callable]], str, Callable],
+        timeout: int = None,
+    ) -> None:
+        """Wait for conditions to be met.
+
+        The object is watched for changes and if the watch fails it falls back to
+        polling.
+
+        Args:
+            conditions: A condition, or a list of conditions which must all be met.
+                Either a string like ``"condition=Ready"``, ``"condition=Ready=False"``,
+                ``"jsonpath={.status.phase}=Running"`` or ``"delete"``, or a callable
+                which is passed this object and returns a bool or an awaitable bool.
+            timeout: Seconds to wait before giving up. Waits forever by default.
+
+        Raises:
+            TimeoutError: If the conditions were not met in time. The message includes
+                the last observed state of the object.
+
+        Example:
+            >>> await pod.wait("condition=Ready", timeout=60)
+            >>> await pod.wait(lambda pod: pod.status.phase == "Succeeded")
+        """
+        await self._wait(conditions, timeout=timeout)
+
+    async def _wait(
+        self,
+        conditions: Union[List[Union[str, Callable]], str, Callable],
+        timeout: int = None,
+        poll_interval: float = 1,
+    ) -> None:
+        """Wait for conditions to be met."""
+        if isinstance(conditions, str) or callable(conditions):
+            conditions = [conditions]
+        only_delete = set(conditions) == {"delete"}
+        found = True
+
+        with anyio.move_on_after(timeout):
+            try:
+                await self._refresh()
+            except NotFoundError:
+                if only_delete:
+                    return
+                found = False
+            if await self._test_conditions(conditions):
+                return
+            try:
+                async for _ in self._watch():
+                    found = True
+                    if await self._test_conditions(conditions):
+                        return
+            except (APIError, httpx.TransportError):
+                pass  # Fall back to polling
+            while True:
+                await anyio.sleep(poll_interval)
+                try:
+                    await self._refresh()
+                    found = True
+                except NotFoundError:
+                    if only_delete:
+                        return
+                    found = False
+                if await self._test_conditions(conditions):
+                    return
+
+        state = self._describe_state() if found else "not found"
+        raise TimeoutError(
+            f"Timed out after {timeout}s waiting for {self.kind} {self.name} "
+            f"to meet {conditions}, last observed state: {state}"
+        )
+
+    async def ready(self) -> bool:
+        """Check if the resource is ready.
+
+        The latest state is read from Kubernetes and checked with the rules for its
+        kind. Pods need their ``Ready`` condition and every container to be ready,
+        workloads like Deployments and StatefulSets need the controller to have
+        observed the latest spec and enough ready and available replicas, and Jobs
+        need to have completed. Any other resource is ready if its ``Ready`` or
+        otherwise its ``Available`` condition is ``"True"``, and isn't ready if it
+        has neither.
+
+        Returns:
+            Whether the resource is ready.
+        """
+        await self._refresh()
+        return await self._ready()
+
+    async def _ready(self) -> bool:
+        """Check if the resource is ready without refreshing it first."""
+        return self._is_ready()
+
+    def _is_ready(self) -> bool:
+        """Check if the resource is ready from its current state."""
+        for condition_type in ("Ready", "Available"):
+            status = self._condition_status(condition_type)
+            if status is not None:
+                return status == "True"
+        return False
+
+    def _replicas_ready(self, desired: int, ready: int, available: int) -> bool:
+        """Check the controller is up to date and enough replicas are ready."""
+        if self._rollout_waiting_for_generation():
+            return False
+        return ready >= desired and available >= desired
+
+    async def wait_ready(self, timeout: int = None) -> None:
+        """Wait for this object to be ready, as reported by ``ready()``.
+
+        Args:
+            timeout: Seconds to wait before giving up. Waits forever by default.
+
+        Raises:
+            TimeoutError: If the object was not ready in time.
+        """
+        # The watch delivers the latest state so there is no need to refresh
+        await self._wait(lambda obj: obj._ready(), timeout=timeout)
 
-        with anyio.fail_after(timeout):
-            try:
-                await self._refresh()
-            except NotFoundError:
-                if set(conditions) == {"delete"}:
-                    return
-            if await self._test_conditions(conditions):
-                return
-            async for _ in self._watch():
-                if await self._test_conditions(conditions):
-                    return
+    async def wait_deleted(self, timeout: int = None) -> None:
+        """Wait for this object to be deleted.
+
+        Args:
+            timeout: Seconds to wait before giving up. Waits forever by default.
+
+        Raises:
+            TimeoutError: If the object still exists after the timeout.
+        """
+        await self._wait("delete", timeout=timeout)
 
     async def annotate(self, annotations: dict = None, **kwargs) -> None:
         """Annotate this object in Kubernetes."""
@@ -422,7 +1381,11 @@ class Endpoints(APIObject):
 
 
 class Event(APIObject):
-    """A Kubernetes Event."""
+    """A Kubernetes Event.
+
+    The helper properties read both the core ``v1`` and the ``events.k8s.io/v1``
+    layout of events, which are two views of the same objects.
+    """
 
     version = "v1"
     endpoint = "events"
@@ -431,6 +1394,55 @@ class Event(APIObject):
     singular = "event"
     namespaced = True
 
+    @property
+    def reason(self) -> Optional[str]:
+        """The machine readable reason for the event, e.g ``"BackOff"``."""
+        return self.raw.get("reason")
+
+    @property
+    def message(self) -> Optional[str]:
+        """The human readable description of the event."""
+        return self.raw.get("message") or self.raw.get("note")
+
+    @property
+    def type(self) -> Optional[str]:
+        """The type of event, ``"Normal"`` or ``"Warning"``."""
+        return self.raw.get("type")
+
+    @property
+    def count(self) -> int:
+        """The number of times this event has occurred."""
+        if self.raw.get("count"):
+            return self.raw["count"]
+        if self.raw.get("deprecatedCount"):
+            return self.raw["deprecatedCount"]
+        return self.raw.get("series", {}).get("count", 1)
+
+    @property
+    def source(self) -> dict:
+        """The ``component`` which reported the event and the ``host`` it ran on."""
+        source = self.raw.get("source") or self.raw.get("deprecatedSource") or {}
+        return {
+            "component": source.get("component")
+            or self.raw.get("reportingComponent")
+            or self.raw.get("reportingController"),
+            "host": source.get("host") or self.raw.get("reportingInstance"),
+        }
+
+    @property
+    def last_timestamp(self) -> datetime.datetime:
+        """When this event most recently occurred."""
+        timestamp = (
+            self.raw.get("lastTimestamp")
+            or self.raw.get("deprecatedLastTimestamp")
+            or self.raw.get("series", {}).get("lastObservedTime")
+            or self.raw.get("eventTime")
+            or self.metadata.get("creationTimestamp")
+        )
+        if not timestamp:
+            return datetime.datetime.min.replace(tzinfo=datetime.timezone.utc)
+        return datetime.datetime.fromisoformat(timestamp.replace("Z", "+00:00"))
+
 
 class LimitRange(APIObject):
     """A Kubernetes LimitRange."""
@@ -452,6 +1464,11 @@ class Namespace(APIObject):
     plural = "namespaces"
     singular = "namespace"
     namespaced = False
+    status_subresource = True
+
+    async def resource_quotas(self) -> List[ResourceQuota]:
+        """Get the ResourceQuotas in this namespace."""
+        return await self.api._get("resourcequotas", namespace=self.name)
 
 
 class Node(APIObject):
@@ -463,6 +1480,7 @@ class Node(APIObject):
     plural = "nodes"
     singular = "node"
     namespaced = False
+    status_subresource = True
 
     @property
     def unschedulable(self):
@@ -476,6 +1494,115 @@ class Node(APIObject):
     async def uncordon(self) -> None:
         await self._patch({"spec": {"unschedulable": False}})
 
+    async def drain(
+        self,
+        ignore_daemonsets: bool = False,
+        delete_emptydir_data: bool = False,
+        force: bool = False,
+        grace_period: int = None,
+        disable_eviction: bool = False,
+        timeout: int = None,
+    ) -> dict:
+        """Cordon this node and evict all of its pods, like ``kubectl drain``.
+
+        Pods are evicted through the eviction API so PodDisruptionBudgets are respected.
+        Evictions which are refused because of a budget are retried with a backoff
+        until they succeed or the timeout is reached. Once evicted the pods are waited
+        on until they are deleted.
+
+        Mirror pods are always skipped as they can't be deleted through the API.
+
+        Args:
+            ignore_daemonsets: Skip pods managed by a DaemonSet instead of failing.
+            delete_emptydir_data: Evict pods with ``emptyDir`` volumes, the data in
+                them will be lost.
+            force: Evict pods which are not managed by a controller, these won't be
+                recreated.
+            grace_period: Seconds each pod has to terminate, overriding the pod's
+                ``terminationGracePeriodSeconds``.
+            disable_eviction: Delete pods directly, bypassing PodDisruptionBudgets.
+            timeout: Seconds to wait for the node to drain. Waits forever by default.
+
+        Returns:
+            A dict with the ``evicted`` and ``skipped`` pods.
+
+        Raises:
+            RuntimeError: If any pods can't be evicted with the given options.
+            TimeoutError: If the pods were not evicted in time.
+        """
+        await self._patch({"spec": {"unschedulable": True}})
+        pods = await self.api._get(
+            "pods",
+            namespace=ALL,
+            field_selector={"spec.nodeName": self.name},
+        )
+        evict, skipped, errors = [], [], []
+        for pod in pods:
+            if "kubernetes.io/config.mirror" in pod.annotations:
+                skipped.append(pod)
+                continue
+            if pod.status.get("phase") in ("Succeeded", "Failed"):
+                evict.append(pod)
+                continue
+            controller = next(
+                (
+                    owner
+                    for owner in pod.metadata.get("ownerReferences", [])
+                    if owner.get("controller")
+                ),
+                None,
+            )
+            if controller is not None and controller["kind"] == "DaemonSet":
+                if ignore_daemonsets:
+                    skipped.append(pod)
+                else:
+                    errors.append(
+                        f"{pod.namespace}/{pod.name} is managed by a DaemonSet"
+                    )
+                continue
+            if controller is None and not force:
+                errors.append(
+                    f"{pod.namespace}/{pod.name} is not managed by a controller"
+                )
+                continue
+            if not delete_emptydir_data and any(
+                "emptyDir" in volume for volume in pod.spec.get("volumes", [])
+            ):
+                errors.append(f"{pod.namespace}/{pod.name} uses emptyDir storage")
+                continue
+            evict.append(pod)
+        if errors:
+            raise RuntimeError(f"Cannot drain node {self.name}: {', '.join(errors)}")
+
+        # Controllers like StatefulSets recreate pods with the same name, so track
+        # which pods were evicted by uid
+        uids = [pod.metadata.get("uid") for pod in evict]
+        with anyio.move_on_after(timeout):
+            for pod in evict:
+                if disable_eviction:
+                    await pod._delete()
+                    continue
+                delay = 1
+                while True:
+                    try:
+                        await pod._evict(grace_period=grace_period)
+                        break
+                    except NotFoundError:
+                        break
+                    except TooManyRequestsError as e:
+                        # Eviction would violate a PodDisruptionBudget, or the server
+                        # is throttling requests
+                        if isinstance(e, DisruptionBudgetError) and e.retry_after:
+                            await anyio.sleep(e.retry_after)
+                        else:
+                            await anyio.sleep(delay)
+                        delay = min(delay * 2, 30)
+            for pod, uid in zip(evict, uids):
+                while not await pod._deleted_or_replaced(uid):
+                    await anyio.sleep(1)
+            return {"evicted": evict, "skipped": skipped}
+        raise TimeoutError(f"Timed out after {timeout}s draining node {self.name}")
+
 
 class PersistentVolumeClaim(APIObject):
     """A Kubernetes PersistentVolumeClaim."""
@@ -486,6 +1613,7 @@ class PersistentVolumeClaim(APIObject):
     plural = "persistentvolumeclaims"
     singular = "persistentvolumeclaim"
     namespaced = True
+    status_subresource = True
 
 
 class PersistentVolume(APIObject):
@@ -497,6 +1625,7 @@ class PersistentVolume(APIObject):
     plural = "persistentvolumes"
     singular = "persistentvolume"
     namespaced = False
+    status_subresource = True
 
 
 class Pod(APIObject):
@@ -508,23 +1637,65 @@ class Pod(APIObject):
     plural = "pods"
     singular = "pod"
     namespaced = True
+    status_subresource = True
 
-    async def ready(self) -> bool:
-        """Check if the pod is ready."""
-        await self._refresh()
-        conditions = list_dict_unpack(
-            self.status.get("conditions", []),
-            key="type",
-            value="status",
+    async def evict(self, grace_period: int = None) -> None:
+        """Evict this pod using the eviction API, respecting PodDisruptionBudgets.
+
+        Unlike :meth:`delete` the eviction is refused if removing the pod would take a
+        PodDisruptionBudget below its minimum available replicas.
+
+        Args:
+            grace_period: Seconds the pod has to terminate, overriding the pod's
+                ``terminationGracePeriodSeconds``.
+
+        Raises:
+            DisruptionBudgetError: If the eviction would violate a PodDisruptionBudget,
+                it can be retried after ``retry_after`` seconds.
+        """
+        await self._evict(grace_period=grace_period)
+
+    async def _evict(self, grace_period: int = None) -> None:
+        """Evict this pod using the eviction API, respecting PodDisruptionBudgets."""
+        eviction = {
+            "apiVersion": "policy/v1",
+            "kind": "Eviction",
+            "metadata": {"name": self.name, "namespace": self.namespace},
+        }
+        if grace_period is not None:
+            eviction["deleteOptions"] = {"gracePeriodSeconds": grace_period}
+        try:
+            async with self.api.call_api(
+                "POST",
+                version=self.version,
+                url=f"{self.endpoint}/{self.name}/eviction",
+                namespace=self.namespace,
+                data=json.dumps(eviction),
+            ):
+                pass
+        except TooManyRequestsError as e:
+            # The server throttling requests is also a 429, budgets are told apart
+            # by the reason of the Status
+            reasons = {e.reason} | {
+                cause.get("reason") for cause in e.details.get("causes", [])
+            }
+            if e.code != 429 or "DisruptionBudget" not in reasons:
+                raise
+            raise DisruptionBudgetError(
+                str(e), request=e.request, response=e.response, status=e.status
+            ) from e
+
+    def _is_ready(self) -> bool:
+        container_statuses = (self.raw.get("status") or {}).get(
+            "containerStatuses", []
         )
         return (
-            "Ready" in conditions
-            and "ContainersReady" in conditions
-            and conditions.get("Ready", "False") == "True"
-            and conditions.get("ContainersReady", "False") == "True"
+            self._condition_status("Ready") == "True"
+            and self._condition_status("ContainersReady") == "True"
+            and all(status.get("ready") for status in container_statuses)
         )
 
-    async def logs(
+    def _logs_params(
         self,
         container=None,
         pretty=None,
@@ -534,17 +1705,30 @@ class Pod(APIObject):
         timestamps=False,
         tail_lines=None,
         limit_bytes=None,
-    ) -> str:
+        follow=False,
+    ) -> dict:
         params = {}
+        container = self._logs_container(container)
         if container is not None:
             params["container"] = container
         if pretty is not None:
             params["pretty"] = pretty
         if previous:
             params["previous"] = "true"
-        if since_seconds is not None and since_time is None:
+        if since_seconds is not None and since_time is not None:
+            raise ValueError("Specify either since_seconds or since_time, not both")
+        if since_seconds is not None:
             params["sinceSeconds"] = int(since_seconds)
-        elif since_time is not None and since_seconds is None:
+        elif since_time is not None:
+            if isinstance(since_time, datetime.datetime):
+                if since_time.tzinfo is None:
+                    since_time = since_time.astimezone()
+                since_time = (
+                    since_time.astimezone(datetime.timezone.utc)
+                    .replace(microsecond=0)
+                    .isoformat()
+                    .replace("+00:00", "Z")
+                )
             params["sinceTime"] = since_time
         if timestamps:
             params["timestamps"] = "true"
@@ -552,6 +1736,76 @@ class Pod(APIObject):
             params["tailLines"] = int(tail_lines)
         if limit_bytes is not None:
             params["limitBytes"] = int(limit_bytes)
+        if follow:
+            params["follow"] = "true"
+        return params
+
+    def _logs_container(self, container: Optional[str]) -> Optional[str]:
+        """Check the container to get logs from, picking the default if there is one."""
+        if "spec" not in self.raw:
+            return container
+        containers = [
+            c["name"]
+            for key in ("initContainers", "containers", "ephemeralContainers")
+            for c in self.spec.get(key, [])
+        ]
+        if container is not None:
+            if container not in containers:
+                raise ValueError(
+                    f"Container {container} not found in pod {self.name}, "
+                    f"choose one of: {', '.join(containers)}"
+                )
+            return container
+        default = self.annotations.get("kubectl.kubernetes.io/default-container")
+        if default in containers:
+            return default
+        if len(self.spec.get("containers", [])) > 1:
+            raise ValueError(
+                f"Pod {self.name} has multiple containers, choose one of: "
+                f"{', '.join(containers)}"
+            )
+        return None
+
+    async def logs(
+        self,
+        container=None,
+        pretty=None,
+        previous=False,
+        since_seconds=None,
+        since_time=None,
+        timestamps=False,
+        tail_lines=None,
+        limit_bytes=None,
+    ) -> str:
+        """Get the Pod logs.
+
+        Args:
+            container: Container to get logs from, which can also be an init container.
+                Defaults to the only container, or the one named by the
+                ``kubectl.kubernetes.io/default-container`` annotation.
+            pretty: Pretty print the output.
+            previous: Return logs from the previous instance of the container, e.g
+                before it crashed.
+            since_seconds: Only return logs newer than a relative duration in seconds.
+            since_time: Only return logs after a ``datetime`` or RFC3339 timestamp.
+            timestamps: Prefix each line with a timestamp.
+            tail_lines: Number of lines from the end of the logs to show.
+            limit_bytes: Number of bytes to read before ending the stream.
+
+        Raises:
+            ValueError: If the pod has multiple containers and none was chosen, or the
+                container doesn't exist.
+        """
+        params = self._logs_params(
+            container=container,
+            pretty=pretty,
+            previous=previous,
+            since_seconds=since_seconds,
+            since_time=since_time,
+            timestamps=timestamps,
+            tail_lines=tail_lines,
+            limit_bytes=limit_bytes,
+        )
 
         async with self.api.call_api(
             "GET",
@@ -559,10 +1813,435 @@ class Pod(APIObject):
             url=f"{self.endpoint}/{self.name}/log",
             namespace=self.namespace,
             params=params,
+            headers={"Accept": "text/plain"},
         ) as resp:
             return resp.text
 
-    def portforward(self, remote_port: int, local_port: int = None) -> int:
+    async def stream_logs(
+        self,
+        container=None,
+        pretty=None,
+        previous=False,
+        since_seconds=None,
+        since_time=None,
+        timestamps=False,
+        tail_lines=None,
+        limit_bytes=None,
+        follow=False,
+    ) -> AsyncGenerator[str, None]:
+        """Stream the Pod logs line by line.
+
+        The generator finishes normally when the server ends the stream, e.g when
+        following the logs of a container that exits. If the consuming task is
+        cancelled, or the generator is closed early, the HTTP response is closed
+        straight away and the cancellation is propagated to the caller, so you
+        can tell the two apart and decide whether to reconnect.
+
+        Args:
+            container: Container to get logs from. Defaults to the only container.
+            pretty: Pretty print the output.
+            previous: Return logs from the previous instance of the container.
+            since_seconds: Only return logs newer than a relative duration in seconds.
+            since_time: Only return logs after a ``datetime`` or RFC3339 timestamp.
+            timestamps: Prefix each line with a timestamp.
+            tail_lines: Number of lines from the end of the logs to show.
+            limit_bytes: Number of bytes to read before ending the stream.
+            follow: Keep the stream open and yield new lines as they are written.
+
+        Example:
+            >>> async for line in pod.stream_logs(follow=True):
+            ...     print(line)
+        """
+        params = self._logs_params(
+            container=container,
+            pretty=pretty,
+            previous=previous,
+            since_seconds=since_seconds,
+            since_time=since_time,
+            timestamps=timestamps,
+            tail_lines=tail_lines,
+            limit_bytes=limit_bytes,
+            follow=follow,
+        )
+        # Streamed requests don't time out between reads, so a followed stream can be
+        # silent for any length of time and only cancellation or the server ends it.
+        async with self.api.call_api(
+            "GET",
+            version=self.version,
+            url=f"{self.endpoint}/{self.name}/log",
+            namespace=self.namespace,
+            params=params,
+            headers={"Accept": "text/plain"},
+            stream=True,
+        ) as resp:
+            async for line in resp.aiter_lines():
+                yield line
+
+    async def exec(
+        self,
+        command: List[str],
+        *,
+        container: str = None,
+        stdin: Union[str, bytes, BinaryIO] = None,
+        stdout: BinaryIO = None,
+        stderr: BinaryIO = None,
+        tty: bool = False,
+        resize: AsyncIterable[Tuple[int, int]] = None,
+        check: bool = True,
+        capture_output: bool = True,
+    ) -> CompletedExec:
+        """Run a command in a container and wait until it completes.
+
+        Behaves like :func:`subprocess.run` and returns a :class:`kr8s._exec.CompletedExec`.
+
+        Args:
+            command: Command to execute.
+            container: Container to execute the command in.
+            stdin: Data or a file-like object to stream to stdin. When the end of
+                a file-like object is reached stdin is closed.
+            stdout: File-like object to stream stdout to as it arrives.
+            stderr: File-like object to stream stderr to as it arrives.
+            tty: Allocate a TTY for the command. Stderr is merged into stdout.
+            resize: Async iterable of ``(columns, rows)`` terminal sizes to forward
+                to the TTY.
+            check: Raise :class:`kr8s.ExecError` if the command exits with a non-zero code.
+            capture_output: Store stdout and stderr on the returned result.
+
+        Example:
+            >>> ex = await pod.exec(["uname", "-a"])
+            >>> print(ex.stdout.decode())
+
+            Stream an interactive shell.
+
+            >>> await pod.exec(["/bin/sh"], stdin=sys.stdin.buffer, stdout=sys.stdout.buffer, tty=True)
+        """
+        return await self._exec(
+            command,
+            container=container,
+            stdin=stdin,
+            stdout=stdout,
+            stderr=stderr,
+            tty=tty,
+            resize=resize,
+            check=check,
+            capture_output=capture_output,
+        )
+
+    async def _exec(
+        self,
+        command: List[str],
+        *,
+        container: str = None,
+        stdin: Union[str, bytes, BinaryIO] = None,
+        stdout: BinaryIO = None,
+        stderr: BinaryIO = None,
+        tty: bool = False,
+        resize: AsyncIterable[Tuple[int, int]] = None,
+        check: bool = True,
+        capture_output: bool = True,
+    ) -> CompletedExec:
+        ex = Exec(
+            self,
+            command,
+            container=container,
+            stdin=stdin,
+            stdout=stdout,
+            stderr=stderr,
+            tty=tty,
+            resize=resize,
+            capture_output=capture_output,
+        )
+        async with ex.run() as process:
+            result = await process.wait()
+        if check:
+            result.check_returncode()
+        return result
+
+    async def copy_from(
+        self,
+        remote_path: str,
+        local_path: Union[str, pathlib.Path],
+        container: str = None,
+    ) -> None:
+        """Copy a file or directory out of a container, like ``kubectl cp``.
+
+        The remote path is copied to the local path, so ``copy_from("/tmp/logs", "out")``
+        creates ``out`` with the contents of ``/tmp/logs``. File modes are preserved,
+        but symlinks and special files are skipped. The container must have ``tar``.
+
+        Args:
+            remote_path: The path of the file or directory in the container.
+            local_path: The path to copy it to.
+            container: The container to copy from. Defaults to the only container.
+
+        Raises:
+            ExecError: If ``tar`` isn't available or the path can't be read.
+            ValueError: If the archive contains paths outside of ``local_path``.
+        """
+        remote = pathlib.PurePosixPath(remote_path)
+        with tempfile.SpooledTemporaryFile(max_size=COPY_SPOOL_SIZE) as archive:
+            await self._copy_exec(
+                ["tar", "cf", "-", "-C", str(remote.parent), remote.name],
+                container=container,
+                stdout=archive,
+            )
+            archive.seek(0)
+            await anyio.to_thread.run_sync(
+                _extract_tar, archive, pathlib.Path(local_path)
+            )
+
+    async def copy_to(
+        self,
+        local_path: Union[str, pathlib.Path],
+        remote_path: str,
+        container: str = None,
+    ) -> None:
+        """Copy a file or directory into a container, like ``kubectl cp``.
+
+        The local path is copied to the remote path, whose parent directory must
+        already exist. File modes are preserved. The container must have ``tar``.
+
+        Args:
+            local_path: The path of the file or directory to copy.
+            remote_path: The path in the container to copy it to.
+            container: The container to copy to. Defaults to the only container.
+
+        Raises:
+            ExecError: If ``tar`` isn't available or the path can't be written.
+        """
+        local = pathlib.Path(local_path)
+        if not local.exists():
+            raise FileNotFoundError(f"{local} does not exist")
+        remote = pathlib.PurePosixPath(remote_path)
+        with tempfile.SpooledTemporaryFile(max_size=COPY_SPOOL_SIZE) as archive:
+            await anyio.to_thread.run_sync(_create_tar, archive, local, remote.name)
+            archive.seek(0)
+            await self._copy_exec(
+                ["tar", "xmf", "-", "-C", str(remote.parent)],
+                container=container,
+                stdin=archive,
+            )
+
+    async def _copy_exec(self, command: List[str], **kwargs) -> None:
+        """Run a tar command for copying files, with a clear error if tar is missing."""
+        stderr = io.BytesIO()
+        try:
+            await self._exec(command, stderr=stderr, capture_output=False, **kwargs)
+        except ExecError as e:
+            message = f"{e} {stderr.getvalue().decode(errors='replace')}"
+            if e.returncode in (126, 127) or "not found" in message:
+                raise ExecError(
+                    f"Copying files requires tar to be installed in the container: "
+                    f"{message.strip()}",
+                    command=command,
+                    returncode=e.returncode,
+                    stderr=stderr.getvalue(),
+                ) from e
+            raise ExecError(
+                f"Copying files failed: {message.strip()}",
+                command=command,
+                returncode=e.returncode,
+                stderr=stderr.getvalue(),
+            ) from e
+
+    async def debug(
+        self,
+        image: str,
+        command: List[str] = None,
+        *,
+        name: str = None,
+        target_container: str = None,
+        share_process_namespace: bool = True,
+        attach: bool = False,
+        stdin: Union[str, bytes, BinaryIO] = None,
+        stdout: BinaryIO = None,
+        stderr: BinaryIO = None,
+        tty: bool = False,
+        timeout: int = 60,
+    ) -> str:
+        """Add an ephemeral debug container to the Pod, like ``kubectl debug``.
+
+        The container is added with the ``ephemeralcontainers`` subresource and can't be
+        removed again, it keeps running until its command exits. This is useful for
+        debugging Pods which don't have a shell or debugging tools in their image.
+
+        Args:
+            image: The image to run the debug container with.
+            command: The command to run. Defaults to the entrypoint of the image.
+            name: The name of the debug container. Defaults to a generated name.
+            target_container: The container to share the process namespace with.
+            share_process_namespace: If ``target_container`` isn't set, share the
+                process namespace with the default container of the Pod, if it has a
+                default container or only has one container.
+            attach: Wait for the container to start and then attach to it, streaming
+                ``stdin``, ``stdout`` and ``stderr`` until its command exits.
+            stdin: Data or a file-like object to stream to stdin when attaching.
+            stdout: File-like object to stream stdout to when attaching.
+            stderr: File-like object to stream stderr to when attaching.
+            tty: Allocate a TTY for the container. Stderr is merged into stdout.
+            timeout: Seconds to wait for the container to be running before attaching.
+
+        Returns:
+            The name of the debug container.
+
+        Raises:
+            NotImplementedError: If the cluster doesn't support ephemeral containers.
+            TimeoutError: If the container didn't start in time when attaching.
+
+        Example:
+            >>> name = await pod.debug("busybox", ["sleep", "3600"])
+            >>> await pod.exec(["ps"], container=name)
+
+            Start an interactive shell.
+
+            >>> await pod.debug("busybox", ["sh"], attach=True, stdin=sys.stdin.buffer, stdout=sys.stdout.buffer, tty=True)
+        """
+        if "spec" not in self.raw:
+            await self._refresh()
+        containers = [c["name"] for c in self.spec.get("containers", [])]
+        if target_container is None and share_process_namespace:
+            default = self.annotations.get("kubectl.kubernetes.io/default-container")
+            if default in containers:
+                target_container = default
+            elif len(containers) == 1:
+                target_container = containers[0]
+        if target_container is not None and target_container not in containers:
+            raise ValueError(
+                f"Container {target_container} not found in pod {self.name}, "
+                f"choose one of: {', '.join(containers)}"
+            )
+        name = name or f"debugger-{uuid.uuid4().hex[:5]}"
+        container = {
+            "name": name,
+            "image": image,
+            "imagePullPolicy": "IfNotPresent",
+            "terminationMessagePolicy": "File",
+            "stdin": stdin is not None,
+            "tty": tty,
+        }
+        if command:
+            container["command"] = command
+        if target_container:
+            container["targetContainerName"] = target_container
+        try:
+            await self._patch(
+                {"spec": {"ephemeralContainers": [container]}},
+                subresource="ephemeralcontainers",
+                type="strategic",
+            )
+        except APIError as e:
+            # A 404 without any details means the subresource itself wasn't found
+            if e.code in (404, 405) and not e.details.get("name"):
+                raise NotImplementedError(
+                    "This cluster does not support ephemeral containers, "
+                    "check that the EphemeralContainers feature gate is enabled"
+                ) from e
+            raise
+        if attach:
+            await self._wait(
+                lambda pod: pod._ephemeral_container_running(name), timeout=timeout
+            )
+            await self._attach(
+                container=name,
+                stdin=stdin,
+                stdout=stdout,
+                stderr=stderr,
+                tty=tty,
+                capture_output=False,
+            )
+        return name
+
+    def _ephemeral_container_running(self, name: str) -> bool:
+        """Check whether an ephemeral container is running, raise if it has exited."""
+        for status in self.status.get("ephemeralContainerStatuses", []):
+            if status["name"] != name:
+                continue
+            if "terminated" in status.get("state", {}):
+                terminated = status["state"]["terminated"]
+                raise RuntimeError(
+                    f"Debug container {name} exited with code "
+                    f"{terminated.get('exitCode')}: {terminated.get('reason')}"
+                )
+            return "running" in status.get("state", {})
+        return False
+
+    async def attach(
+        self,
+        *,
+        container: str = None,
+        stdin: Union[str, bytes, BinaryIO] = None,
+        stdout: BinaryIO = None,
+        stderr: BinaryIO = None,
+        tty: bool = False,
+        resize: AsyncIterable[Tuple[int, int]] = None,
+        capture_output: bool = True,
+        timeout: float = None,
+    ) -> CompletedExec:
+        """Attach to the main process of a running container, like ``kubectl attach``.
+
+        Unlike :meth:`exec` no new process is started, the streams of the process the
+        container is already running are used. For stdin to be sent the container must
+        have ``stdin: true`` set in its spec.
+
+        Args:
+            container: Container to attach to.
+            stdin: Data or a file-like object to stream to stdin.
+            stdout: File-like object to stream stdout to as it arrives.
+            stderr: File-like object to stream stderr to as it arrives.
+            tty: Attach to the container's TTY, the container must have ``tty: true``
+                set. Stderr is merged into stdout.
+            resize: Async iterable of ``(columns, rows)`` terminal sizes to forward
+                to the TTY.
+            capture_output: Store stdout and stderr on the returned result.
+            timeout: Seconds to stay attached for, waits until the process exits by
+                default. If the process is still running when the timeout is reached
+                the ``returncode`` of the result is ``None``.
+
+        Example:
+            >>> await pod.attach(stdin=sys.stdin.buffer, stdout=sys.stdout.buffer, tty=True)
+        """
+        return await self._attach(
+            container=container,
+            stdin=stdin,
+            stdout=stdout,
+            stderr=stderr,
+            tty=tty,
+            resize=resize,
+            capture_output=capture_output,
+            timeout=timeout,
+        )
+
+    async def _attach(
+        self,
+        *,
+        container: str = None,
+        stdin: Union[str, bytes, BinaryIO] = None,
+        stdout: BinaryIO = None,
+        stderr: BinaryIO = None,
+        tty: bool = False,
+        resize: AsyncIterable[Tuple[int, int]] = None,
+        capture_output: bool = True,
+        timeout: float = None,
+    ) -> CompletedExec:
+        ex = Exec(
+            self,
+            [],
+            container=container,
+            stdin=stdin,
+            stdout=stdout,
+            stderr=stderr,
+            tty=tty,
+            resize=resize,
+            capture_output=capture_output,
+            subresource="attach",
+        )
+        with anyio.move_on_after(timeout):
+            async with ex.run() as process:
+                return await process.wait()
+        # Detached before the process exited
+        return ex._result()
+
+    def portforward(self, remote_port: Union[int, str], local_port: int = None) -> int:
         """Port forward a pod.
 
         Returns an instance of :class:`kr8s.portforward.PortForward` for this Pod.
@@ -610,15 +2289,16 @@ class ReplicationController(APIObject):
     plural = "replicationcontrollers"
     singular = "replicationcontroller"
     namespaced = True
+    status_subresource = True
     scalable = True
-
-    async def ready(self):
-        """Check if the deployment is ready."""
-        await self._refresh()
-        return (
-            self.raw["status"].get("observedGeneration", 0)
-            >= self.raw["metadata"]["generation"]
-            and self.raw["status"].get("readyReplicas", 0) == self.replicas
+    scalable_subresource = True
+
+    def _is_ready(self) -> bool:
+        status = self.raw.get("status", {})
+        return self._replicas_ready(
+            self.raw["spec"].get("replicas", 1),
+            status.get("readyReplicas", 0),
+            status.get("availableReplicas", 0),
         )
 
 
@@ -631,6 +2311,28 @@ class ResourceQuota(APIObject):
     plural = "resourcequotas"
     singular = "resourcequota"
     namespaced = True
+    status_subresource = True
+
+    @property
+    def hard(self) -> Dict[str, Decimal]:
+        """The enforced limit of each resource, from ``status.hard``."""
+        hard = self.raw.get("status", {}).get("hard", {})
+        return {k: parse_quantity(v) for k, v in hard.items()}
+
+    @property
+    def used(self) -> Dict[str, Decimal]:
+        """The current usage of each resource, from ``status.used``."""
+        used = self.raw.get("status", {}).get("used", {})
+        return {k: parse_quantity(v) for k, v in used.items()}
+
+    @property
+    def usage(self) -> Dict[str, QuotaUsage]:
+        """The used and hard quantities of each resource in the quota."""
+        used = self.used
+        return {
+            k: QuotaUsage(used.get(k, Decimal(0)), hard)
+            for k, hard in self.hard.items()
+        }
 
 
 class Secret(APIObject):
@@ -664,6 +2366,7 @@ class Service(APIObject):
     plural = "services"
     singular = "service"
     namespaced = True
+    status_subresource = True
 
     async def proxy_http_request(
         self, method: str, path: str, port: Optional[int] = None, **kwargs: Any
@@ -721,17 +2424,21 @@ class Service(APIObject):
         """Return a list of ready Pods for this Service."""
         pods = await self.api._get(
             "pods",
-            label_selector=dict_to_selector(self.spec["selector"]),
+            label_selector=LabelSelector.from_dict(self.spec["selector"]),
             namespace=self.namespace,
         )
-        return [pod for pod in pods if await pod.ready()]
+        # The Pods were just listed so there is no need to refresh them
+        return [pod for pod in pods if pod._is_ready()]
 
     async def ready(self) -> bool:
         """Check if the service is ready."""
+        return await self._ready()
+
+    async def _ready(self) -> bool:
         pods = await self._ready_pods()
         return len(pods) > 0
 
-    def portforward(self, remote_port: int, local_port: int = None) -> int:
+    def portforward(self, remote_port: Union[int, str], local_port: int = None) -> int:
         """Port forward a service.
 
         Returns an instance of :class:`kr8s.portforward.PortForward` for this Service.
@@ -783,6 +2490,41 @@ class DaemonSet(APIObject):
     plural = "daemonsets"
     singular = "daemonset"
     namespaced = True
+    status_subresource = True
+    restartable = True
+
+    def _is_ready(self) -> bool:
+        status = self.raw.get("status", {})
+        return self._replicas_ready(
+            status.get("desiredNumberScheduled", 0),
+            status.get("numberReady", 0),
+            status.get("numberAvailable", 0),
+        )
+
+    def _rollout_progress(self) -> dict:
+        status = self.raw.get("status", {})
+        desired = status.get("desiredNumberScheduled", 0)
+        progress = {
+            "desired": desired,
+            "updated": status.get("updatedNumberScheduled", 0),
+            "ready": status.get("numberReady", 0),
+            "available": status.get("numberAvailable", 0),
+            "done": False,
+        }
+        if self._rollout_waiting_for_generation():
+            progress["message"] = "Waiting for daemon set spec update to be observed"
+        elif progress["updated"] < desired:
+            progress["message"] = (
+                f"{progress['updated']} out of {desired} new pods have been updated"
+            )
+        elif progress["available"] < desired:
+            progress["message"] = (
+                f"{progress['available']} of {desired} updated pods are available"
+            )
+        else:
+            progress["message"] = "Successfully rolled out"
+            progress["done"] = True
+        return progress
 
 
 class Deployment(APIObject):
@@ -794,26 +2536,69 @@ class Deployment(APIObject):
     plural = "deployments"
     singular = "deployment"
     namespaced = True
+    status_subresource = True
     scalable = True
+    scalable_subresource = True
+    restartable = True
 
     async def pods(self) -> List[Pod]:
         """Return a list of Pods for this Deployment."""
         pods = await self.api._get(
             "pods",
-            label_selector=dict_to_selector(self.spec["selector"]["matchLabels"]),
+            label_selector=LabelSelector.from_spec(self.spec["selector"]),
             namespace=self.namespace,
         )
         return pods
 
-    async def ready(self):
-        """Check if the deployment is ready."""
-        await self._refresh()
-        return (
-            self.raw["status"].get("observedGeneration", 0)
-            >= self.raw["metadata"]["generation"]
-            and self.raw["status"].get("readyReplicas", 0) == self.replicas
+    def _is_ready(self) -> bool:
+        status = self.raw.get("status", {})
+        if self._condition_status("Available") == "False":
+            return False
+        return self._replicas_ready(
+            self.raw["spec"].get("replicas", 1),
+            status.get("readyReplicas", 0),
+            status.get("availableReplicas", 0),
         )
 
+    def _rollout_progress(self) -> dict:
+        status = self.raw.get("status", {})
+        desired = self.replicas or 0
+        progress = {
+            "desired": desired,
+            "updated": status.get("updatedReplicas", 0),
+            "ready": status.get("readyReplicas", 0),
+            "available": status.get("availableReplicas", 0),
+            "done": False,
+        }
+        if self._rollout_waiting_for_generation():
+            progress["message"] = "Waiting for deployment spec update to be observed"
+            return progress
+        for condition in status.get("conditions", []):
+            if (
+                condition["type"] == "Progressing"
+                and condition.get("reason") == "ProgressDeadlineExceeded"
+            ):
+                raise RolloutError(
+                    f"Deployment {self.name} exceeded its progress deadline"
+                )
+        if progress["updated"] < desired:
+            progress["message"] = (
+                f"{progress['updated']} out of {desired} new replicas have been updated"
+            )
+        elif status.get("replicas", 0) > progress["updated"]:
+            old = status["replicas"] - progress["updated"]
+            progress["message"] = f"{old} old replicas are pending termination"
+        elif progress["available"] < progress["updated"]:
+            progress["message"] = (
+                f"{progress['available']} of {progress['updated']} "
+                "updated replicas are available"
+            )
+        else:
+            progress["message"] = "Successfully rolled out"
+            progress["done"] = True
+        return progress
+
+
 
 class ReplicaSet(APIObject):
     """A Kubernetes ReplicaSet."""
@@ -824,7 +2609,17 @@ class ReplicaSet(APIObject):
     plural = "replicasets"
     singular = "replicaset"
     namespaced = True
+    status_subresource = True
     scalable = True
+    scalable_subresource = True
+
+    def _is_ready(self) -> bool:
+        status = self.raw.get("status", {})
+        return self._replicas_ready(
+            self.raw["spec"].get("replicas", 1),
+            status.get("readyReplicas", 0),
+            status.get("availableReplicas", 0),
+        )
 
 
 class StatefulSet(APIObject):
@@ -836,7 +2631,55 @@ class StatefulSet(APIObject):
     plural = "statefulsets"
     singular = "statefulset"
     namespaced = True
+    status_subresource = True
     scalable = True
+    scalable_subresource = True
+    restartable = True
+
+    def _is_ready(self) -> bool:
+        status = self.raw.get("status", {})
+        ready = status.get("readyReplicas", 0)
+        return self._replicas_ready(
+            self.raw["spec"].get("replicas", 1),
+            ready,
+            # Older clusters don't report available replicas
+            status.get("availableReplicas", ready),
+        )
+
+    def _rollout_progress(self) -> dict:
+        status = self.raw.get("status", {})
+        desired = self.replicas or 0
+        progress = {
+            "desired": desired,
+            "updated": status.get("updatedReplicas", 0),
+            "ready": status.get("readyReplicas", 0),
+            "available": status.get("availableReplicas", 0),
+            "done": False,
+        }
+        partition = (
+            self.spec.get("updateStrategy", {})
+            .get("rollingUpdate", {})
+            .get("partition", 0)
+        )
+        if self._rollout_waiting_for_generation():
+            progress["message"] = "Waiting for statefulset spec update to be observed"
+        elif progress["ready"] < desired:
+            progress["message"] = f"{progress['ready']} of {desired} pods are ready"
+        elif partition and progress["updated"] < desired - partition:
+            progress["message"] = (
+                f"{progress['updated']} of {desired - partition} "
+                "partitioned pods have been updated"
+            )
+        elif not partition and status.get("updateRevision") != status.get(
+            "currentRevision"
+        ):
+            progress["message"] = (
+                f"{progress['updated']} out of {desired} new pods have been updated"
+            )
+        else:
+            progress["message"] = "Successfully rolled out"
+            progress["done"] = True
+        return progress
 
 
 ## autoscaling/v1 objects
@@ -851,6 +2694,7 @@ class HorizontalPodAutoscaler(APIObject):
     plural = "horizontalpodautoscalers"
     singular = "horizontalpodautoscaler"
     namespaced = True
+    status_subresource = True
 
 
 ## batch/v1 objects
@@ -865,6 +2709,7 @@ class CronJob(APIObject):
     plural = "cronjobs"
     singular = "cronjob"
     namespaced = True
+    status_subresource = True
 
 
 class Job(APIObject):
@@ -876,9 +2721,17 @@ class Job(APIObject):
     plural = "jobs"
     singular = "job"
     namespaced = True
+    status_subresource = True
     scalable = True
     scalable_spec = "parallelism"
 
+    def _is_ready(self) -> bool:
+        # A Job which has failed will never complete
+        return (
+            self._condition_status("Complete") == "True"
+            and self._condition_status("Failed") != "True"
+        )
+
 
 ## networking.k8s.io/v1 objects
 
@@ -903,6 +2756,7 @@ class Ingress(APIObject):
     plural = "ingresses"
     singular = "ingress"
     namespaced = True
+    status_subresource = True
 
 
 class NetworkPolicy(APIObject):
@@ -928,6 +2782,7 @@ class PodDisruptionBudget(APIObject):
     plural = "poddisruptionbudgets"
     singular = "poddisruptionbudget"
     namespaced = True
+    status_subresource = True
 
 
 ## rbac.authorization.k8s.io/v1 objects
@@ -989,6 +2844,54 @@ class CustomResourceDefinition(APIObject):
     plural = "customresourcedefinitions"
     singular = "customresourcedefinition"
     namespaced = False
+    status_subresource = True
+
+
+## metrics.k8s.io/v1beta1 objects
+
+
+class NodeMetrics(APIObject):
+    """The resource usage of a Kubernetes Node, as reported by metrics-server."""
+
+    version = "metrics.k8s.io/v1beta1"
+    endpoint = "nodes"
+    kind = "NodeMetrics"
+    plural = "nodemetrics"
+    singular = "nodemetrics"
+    namespaced = False
+
+    @property
+    def usage(self) -> Dict[str, Decimal]:
+        """The usage of each resource, CPU in cores and memory in bytes."""
+        return {k: parse_quantity(v) for k, v in self.raw.get("usage", {}).items()}
+
+
+class PodMetrics(APIObject):
+    """The resource usage of a Kubernetes Pod, as reported by metrics-server."""
+
+    version = "metrics.k8s.io/v1beta1"
+    endpoint = "pods"
+    kind = "PodMetrics"
+    plural = "podmetrics"
+    singular = "podmetrics"
+    namespaced = True
+
+    @property
+    def containers(self) -> Dict[str, Dict[str, Decimal]]:
+        """The usage of each resource by each container, keyed by container name."""
+        return {
+            c["name"]: {k: parse_quantity(v) for k, v in c.get("usage", {}).items()}
+            for c in self.raw.get("containers", [])
+        }
+
+    @property
+    def usage(self) -> Dict[str, Decimal]:
+        """The total usage of each resource by all containers in the Pod."""
+        usage = {}
+        for container in self.containers.values():
+            for k, v in container.items():
+                usage[k] = usage.get(k, Decimal(0)) + v
+        return usage
 
 
 ## meta.k8s.io/v1 objects
@@ -1015,6 +2918,110 @@ class Table(APIObject):
         return self._raw["columnDefinitions"]
 
 
+class PartialObjectMetadata(APIObject):
+    """The metadata of a Kubernetes resource, without its spec or status.
+
+    Returned when listing resources with ``metadata_only=True``, which is much cheaper
+    than listing them in full when only names, labels or owner references are needed.
+    """
+
+    version = "meta.k8s.io/v1"
+    endpoint = "partialobjectmetadata"
+    kind = "PartialObjectMetadata"
+    plural = "partialobjectmetadata"
+    singular = "partialobjectmetadata"
+    namespaced = False
+
+    @property
+    def namespace(self) -> Optional[str]:
+        """Namespace of the resource, or ``None`` if it is cluster scoped."""
+        return self.raw["metadata"].get("namespace")
+
+
+def _create_tar(archive: BinaryIO, path: pathlib.Path, name: str) -> None:
+    """Write a tar archive of a local path, renamed to ``name``."""
+    with tarfile.open(fileobj=archive, mode="w") as tar:
+        tar.add(path, arcname=name)
+
+
+def _extract_tar(archive: BinaryIO, destination: pathlib.Path) -> None:
+    """Extract a tar archive of a single path to ``destination``.
+
+    The leading path component of each entry is replaced with the destination, the
+    same as ``kubectl cp``. Entries which would be written outside of the destination
+    raise an error, and links and special files are skipped.
+    """
+    destination = destination.absolute()
+    directories = []
+    with tarfile.open(fileobj=archive, mode="r|") as tar:
+        for member in tar:
+            name = pathlib.PurePosixPath(member.name)
+            if name.is_absolute() or ".." in name.parts:
+                raise ValueError(f"Refusing to extract {member.name} from archive")
+            target = destination.joinpath(*name.parts[1:])
+            if os.path.commonpath([destination, target]) != str(destination):
+                raise ValueError(f"Refusing to extract {member.name} from archive")
+            # The archive comes from the container, so never set setuid, setgid or
+            # sticky bits
+            mode = member.mode & 0o777
+            if member.isdir():
+                target.mkdir(parents=True, exist_ok=True)
+                directories.append((target, mode))
+            elif member.isfile():
+                target.parent.mkdir(parents=True, exist_ok=True)
+                with tar.extractfile(member) as src, open(target, "wb") as dst:
+                    while chunk := src.read(COPY_CHUNK_SIZE):
+                        dst.write(chunk)
+                target.chmod(mode)
+    # Directories may be read only, so set their modes once their contents have been
+    # written, deepest first
+    directories.sort(key=lambda directory: len(directory[0].parts), reverse=True)
+    for target, mode in directories:
+        target.chmod(mode)
+
+
+def _jsonpath_findall(expression: str, data: dict) -> List[Any]:
+    """Evaluate a kubectl style JSONPath expression, which may be wrapped in braces."""
+    expression = expression.strip()
+    if expression.startswith("{") and expression.endswith("}"):
+        expression = expression[1:-1]
+    try:
+        return jsonpath.findall(expression, data)
+    except jsonpath.JSONPathError as e:
+        raise ValueError(f"Invalid JSONPath {expression!r}: {e}") from e
+
+
+def _write_params(
+    dry_run: bool = False, field_validation: str = None
+) -> Optional[Dict[str, str]]:
+    """Build the query parameters shared by requests which write objects."""
+    params = {}
+    if dry_run:
+        params["dryRun"] = "All"
+    if field_validation is not None:
+        if field_validation not in FIELD_VALIDATION_MODES:
+            raise ValueError(
+                f"Unknown field validation {field_validation!r}, "
+                f"must be one of {', '.join(FIELD_VALIDATION_MODES)}"
+            )
+        params["fieldValidation"] = field_validation
+    return params or None
+
+
+def _validate_json_patch(patch: Any) -> None:
+    """Check a JSON patch is a list of operations before sending it."""
+    if not isinstance(patch, list):
+        raise ValueError("A JSON patch must be a list of operations")
+    for operation in patch:
+        if not isinstance(operation, dict) or "path" not in operation:
+            raise ValueError(f"Invalid JSON patch operation {operation!r}")
+        if operation.get("op") not in JSON_PATCH_OPERATIONS:
+            raise ValueError(
+                f"Invalid JSON patch operation {operation.get('op')!r}, "
+                f"must be one of {', '.join(sorted(JSON_PATCH_OPERATIONS))}"
+            )
+
+
 def get_class(
     kind: str, version: Optional[str] = None, _asyncio: bool = True
 ) -> Type[APIObject]:
@@ -1048,7 +3055,15 @@ def get_class(
 
 
 def new_class(
-    kind: str, version: str = None, asyncio: bool = True, namespaced=True
+    kind: str,
+    version: str = None,
+    asyncio: bool = True,
+    namespaced=True,
+    scalable: bool = False,
+    scalable_spec: str = "replicas",
+    scalable_subresource: bool = False,
+    status_subresource: bool = False,
+    plural: str = None,
 ) -> Type[APIObject]:
     """Create a new APIObject subclass.
 
@@ -1057,12 +3072,21 @@ def new_class(
         version: The Kubernetes API version.
         asyncio: Whether to use asyncio or not.
         namespaced: Whether the resource is namespaced or not.
+        scalable: Whether the resource can be scaled.
+        scalable_spec: The dot separated path to the replicas field in the spec.
+        scalable_subresource: Whether the resource has a ``scale`` subresource,
+            implies ``scalable``.
+        status_subresource: Whether the resource has a ``status`` subresource.
+        plural: The plural name of the resource used in its API path, defaults to the
+            lowercase kind with an ``s`` appended.
 
     Returns:
         A new APIObject subclass.
     """
     if version is None:
         version = "v1"
+    if plural is None:
+        plural = kind.lower() + "s"
     return type(
         kind,
         (APIObject,),
@@ -1070,16 +3094,23 @@ def new_class(
             "kind": kind,
             "version": version,
             "_asyncio": asyncio,
-            "endpoint": kind.lower() + "s",
-            "plural": kind.lower() + "s",
+            "endpoint": plural,
+            "plural": plural,
             "singular": kind.lower(),
             "namespaced": namespaced,
+            "scalable": scalable or scalable_subresource,
+            "scalable_spec": scalable_spec,
+            "scalable_subresource": scalable_subresource,
+            "status_subresource": status_subresource,
         },
     )
 
 
 def object_from_spec(
-    spec: dict, api: Api = None, allow_unknown_type: bool = False
+    spec: dict,
+    api: Api = None,
+    allow_unknown_type: bool = False,
+    _asyncio: bool = True,
 ) -> APIObject:
     """Create an APIObject from a Kubernetes resource spec.
 
@@ -1093,15 +3124,73 @@ def object_from_spec(
         ValueError: If the resource kind or API version is not supported.
     """
     try:
-        cls = get_class(spec["kind"], spec["apiVersion"])
+        cls = get_class(spec["kind"], spec["apiVersion"], _asyncio=_asyncio)
     except KeyError:
         if allow_unknown_type:
-            cls = new_class(spec["kind"], spec["apiVersion"])
+            cls = new_class(spec["kind"], spec["apiVersion"], asyncio=_asyncio)
         else:
             raise
     return cls(spec, api=api)
 
 
+def _objects_from_docs(
+    docs: List[dict], api: Api = None, _asyncio: bool = True
+) -> List[APIObject]:
+    objects = []
+    for doc in docs:
+        if doc is None:
+            continue
+        # Lists such as the output of kubectl get -o yaml hold their objects in items
+        if doc.get("kind", "").endswith("List") and "items" in doc:
+            objects.extend(_objects_from_docs(doc["items"], api, _asyncio))
+        else:
+            objects.append(
+                object_from_spec(
+                    doc, api=api, allow_unknown_type=True, _asyncio=_asyncio
+                )
+            )
+    return objects
+
+
+def objects_from_yaml(
+    data: Union[str, bytes], api: Api = None, _asyncio: bool = True
+) -> List[APIObject]:
+    """Create APIObjects from a YAML manifest.
+
+    The manifest may contain many documents separated by ``---``, and ``List`` objects
+    are expanded into their items. Kinds which aren't known to ``kr8s`` are created
+    with :func:`new_class`.
+
+    Args:
+        data: The YAML manifest.
+        api: An optional API instance to use.
+
+    Returns:
+        A list of APIObject subclass instances.
+    """
+    return _objects_from_docs(yaml.safe_load_all(data), api=api, _asyncio=_asyncio)
+
+
+def objects_from_json(
+    data: Union[str, bytes], api: Api = None, _asyncio: bool = True
+) -> List[APIObject]:
+    """Create APIObjects from a JSON manifest.
+
+    The manifest may be a single object, an array of objects or a ``List`` object.
+
+    Args:
+        data: The JSON manifest.
+        api: An optional API instance to use.
+
+    Returns:
+        A list of APIObject subclass instances.
+    """
+    docs = json.loads(data)
+    if isinstance(docs, dict):
+        docs = [docs]
+    return _objects_from_docs(docs, api=api, _asyncio=_asyncio)
+
+
 async def object_from_name_type(
     name: str, namespace: str = None, api: Api = None
 ) -> APIObject:
@@ -1154,9 +3243,5 @@ async def objects_from_files(
     objects = []
     for file in files:
         with open(file, "r") as f:
-            for doc in yaml.safe_load_all(f):
-                if doc is not None:
-                    objects.append(
-                        object_from_spec(doc, api=api, allow_unknown_type=True)
-                    )
+            objects.extend(objects_from_yaml(f, api=api))
     return objects
@@ -0,0 +1,137 @@
+# SPDX-FileCopyrightText: Copyright (c) 2023, Dask Developers, NVIDIA
+# SPDX-License-Identifier: BSD 3-Clause License
+from __future__ import annotations
+
+from typing import TYPE_CHECKING, AsyncGenerator, Dict, List, Optional, Tuple, Union
+
+import httpx
+
+from ._exceptions import ResourceVersionTooOldError
+from ._selectors import FieldSelector, LabelSelector
+
+if TYPE_CHECKING:
+    from ._api import Api
+    from ._objects import APIObject
+
+
+class ListPager:
+    """List Kubernetes resources one page at a time.
+
+    Each page is requested with ``limit`` and the ``continue`` token returned with the
+    previous page, so large collections can be consumed without fetching everything in
+    a single request.
+
+    Args:
+        ``api`` (Api): The API client to make requests with.
+
+        ``kind`` (str): The kind of resource to list.
+
+        ``namespace`` (str, optional): The namespace to list resources in.
+
+        ``label_selector`` (str, dict or LabelSelector, optional): Filter by labels.
+
+        ``field_selector`` (str, dict or FieldSelector, optional): Filter by fields.
+
+        ``limit`` (int, optional): The maximum number of resources per page.
+        Defaults to ``500``.
+
+        ``restart_on_expired`` (bool, optional): If the continue token expires before
+        the last page is fetched start again from the first page instead of raising
+        :class:`kr8s.ResourceVersionTooOldError`. Pages returned before the restart
+        should be discarded, ``restarts`` counts how many times this has happened.
+
+        ``metadata_only`` (bool, optional): Only fetch the metadata of the resources,
+        which are returned as :class:`kr8s.objects.PartialObjectMetadata` objects.
+
+        ``strip_managed_fields`` (bool, optional): Remove ``metadata.managedFields``
+        from the resources to save memory.
+
+    Attributes:
+        ``continue_token`` (str): The token for the next page, ``None`` when done.
+
+        ``resource_version`` (str): The resourceVersion of the collection, which can be
+        passed as ``since`` to :meth:`kr8s.Api.watch` once the last page is fetched.
+
+    Example:
+        >>> pager = api.pager("pods", namespace=kr8s.ALL, limit=100)
+        >>> async for pods in pager.pages():
+        ...     print(len(pods))
+    """
+
+    def __init__(
+        self,
+        api: Api,
+        kind: str,
+        namespace: str = None,
+        label_selector: Union[str, Dict, LabelSelector] = None,
+        field_selector: Union[str, Dict, FieldSelector] = None,
+        limit: int = 500,
+        restart_on_expired: bool = False,
+        metadata_only: bool = False,
+        strip_managed_fields: bool = False,
+        **kwargs,
+    ) -> None:
+        self.api = api
+        self.kind = kind
+        self.namespace = namespace
+        self.label_selector = label_selector
+        self.field_selector = field_selector
+        self.limit = limit
+        self.restart_on_expired = restart_on_expired
+        self.metadata_only = metadata_only
+        self.strip_managed_fields = strip_managed_fields
+        self.continue_token: Optional[str] = None
+        self.resource_version: Optional[str] = None
+        self.restarts = 0
+        self.done = False
+        self._kwargs = kwargs
+
+    async def next(self) -> Tuple[List[APIObject], bool]:
+        """Fetch the next page.
+
+        Returns:
+            A tuple of the resources in the page and whether there are more pages.
+        """
+        return await self._next()
+
+    async def _next(self) -> Tuple[List[APIObject], bool]:
+        if self.done:
+            return [], False
+        params = {"limit": int(self.limit)}
+        if self.continue_token:
+            params["continue"] = self.continue_token
+        try:
+            async with self.api._get_kind(
+                self.kind,
+                namespace=self.namespace,
+                label_selector=self.label_selector,
+                field_selector=self.field_selector,
+                params=params,
+                metadata_only=self.metadata_only,
+                **self._kwargs,
+            ) as (obj_cls, response):
+                resourcelist = response.json()
+        except httpx.HTTPStatusError as e:
+            if e.response.status_code != 410 or not self.continue_token:
+                raise
+            if not self.restart_on_expired:
+                raise ResourceVersionTooOldError(
+                    "The continue token has expired, the list must be restarted"
+                ) from e
+            self.continue_token = None
+            self.restarts += 1
+            return await self._next()
+        metadata = resourcelist.get("metadata", {})
+        self.resource_version = metadata.get("resourceVersion")
+        self.continue_token = metadata.get("continue") or None
+        self.done = self.continue_token is None
+        objects = self.api._list_objects(
+            obj_cls, resourcelist, self.strip_managed_fields
+        )
+        return objects, not self.done
+
+    async def pages(self) -> AsyncGenerator[List[APIObject], None]:
+        """Iterate over the remaining pages."""
+        while not self.done:
+            objects, _ = await self._next()
+            yield objects
@@ -6,7 +6,7 @@ import asyncio
 import random
 import socket
 from contextlib import asynccontextmanager
-from typing import TYPE_CHECKING, BinaryIO
+from typing import TYPE_CHECKING, BinaryIO, Union
 
 import aiohttp
 import sniffio
@@ -25,6 +25,8 @@ class PortForward:
 
     .. note::
         The ``ready_pods`` method should return a list of Pods that are ready to accept connections.
+        If the Pod being forwarded to goes away the connection will be re-established to
+        another ready Pod.
 
     .. warning:
         Currently Port Forwards only work when using ``asyncio`` and not ``trio``.
@@ -32,7 +34,9 @@ class PortForward:
     Args:
         ``resource`` (Pod or Resource): The Pod or Resource to forward to.
 
-        ``remote_port`` (int): The port on the Pod to forward to.
+        ``remote_port`` (int or str): The port on the Pod to forward to. This can also be the
+        name of a container port, or when forwarding to a Service the name of a port in the
+        Service spec which will be translated to its ``targetPort``.
 
         ``local_port`` (int, optional): The local port to listen on. Defaults to 0, which will choose a random port.
 
@@ -58,7 +62,7 @@ class PortForward:
     """
 
     def __init__(
-        self, resource: APIObject, remote_port: int, local_port: int = None
+        self, resource: APIObject, remote_port: Union[int, str], local_port: int = None
     ) -> None:
         if sniffio.current_async_library() != "asyncio":
             raise RuntimeError(
@@ -116,14 +120,41 @@ class PortForward:
         self._bg_future.set_result(None)
         self._bg_task = None
 
+    async def _select_pod(self) -> None:
+        """Choose a ready Pod to forward to, keeping the current one if it is still ready."""
+        if self.pod is self._resource:
+            return
+        pods = await self._resource.ready_pods()
+        if self.pod in pods:
+            return
+        try:
+            self.pod = random.choice(pods)
+        except IndexError:
+            raise RuntimeError("No ready pods found")
+
+    def _resolve_remote_port(self) -> int:
+        """Translate a named port to the container port number on the current Pod."""
+        port = self.remote_port
+        if self.pod is not self._resource and isinstance(port, str):
+            for service_port in self._resource.raw["spec"].get("ports", []):
+                if service_port.get("name") == port:
+                    port = service_port.get("targetPort", service_port["port"])
+                    break
+            else:
+                raise ValueError(f"{self._resource.kind} has no port named {port}")
+        if isinstance(port, str) and not port.isdigit():
+            for container in self.pod.raw["spec"]["containers"]:
+                for container_port in container.get("ports", []):
+                    if container_port.get("name") == port:
+                        return container_port["containerPort"]
+            raise ValueError(f"Pod {self.pod.name} has no container port named {port}")
+        return int(port)
+
     @asynccontextmanager
     async def _run(self) -> int:
         """Start the port forward and yield the local port."""
-        if not self.pod:
-            try:
-                self.pod = random.choice(await self._resource.ready_pods())
-            except IndexError:
-                raise RuntimeError("No ready pods found")
+        await self._select_pod()
+        self._resolve_remote_port()
         self.server = await asyncio.start_server(
             self._sync_sockets, port=self.local_port, host="0.0.0.0"
         )
@@ -138,6 +169,13 @@ class PortForward:
     async def _connect_websocket(self) -> None:
         while self.running:
             self.connection_attempts += 1
+            if self.connection_attempts > 1:
+                # The Pod we were forwarding to may have gone away
+                try:
+                    await self._select_pod()
+                except RuntimeError:
+                    await asyncio.sleep(0.1)
+                    continue
             try:
                 async with self.pod.api.open_websocket(
                     version=self.pod.version,
@@ -146,7 +184,7 @@ class PortForward:
                     params={
                         "name": self.pod.name,
                         "namespace": self.pod.namespace,
-                        "ports": f"{self.remote_port}",
+                        "ports": f"{self._resolve_remote_port()}",
                         "_preload_content": "false",
                     },
                 ) as websocket:
@@ -0,0 +1,87 @@
+# SPDX-FileCopyrightText: Copyright (c) 2023, Dask Developers, NVIDIA
+# SPDX-License-Identifier: BSD 3-Clause License
+from __future__ import annotations
+
+import threading
+import time
+from typing import Callable, Optional
+
+import anyio
+
+
+class RateLimiter:
+    """Limit the rate of requests made to the Kubernetes API with a token bucket.
+
+    Up to ``burst`` requests can be made at once, after which requests are delayed so
+    that on average no more than ``qps`` are sent per second. The defaults match the
+    client-go defaults of 5 QPS with a burst of 10.
+
+    Each request takes a token when it is sent, so long running requests such as
+    watches only take one token however long they run for. Cancelling a request while
+    it is being delayed returns its token.
+
+    Pass a limiter to :func:`kr8s.api` with the ``rate_limit`` keyword, or
+    ``rate_limit=False`` to disable rate limiting.
+
+    Args:
+        ``qps`` (float): The average number of requests per second.
+
+        ``burst`` (int): The number of requests that can be made at once.
+
+        ``on_throttle`` (callable): Called with the delay in seconds each time a
+        request is delayed.
+
+    Attributes:
+        ``throttled`` (int): The number of requests which have been delayed.
+
+        ``throttled_seconds`` (float): The total time requests have been delayed for.
+    """
+
+    def __init__(
+        self,
+        qps: float = 5.0,
+        burst: int = 10,
+        on_throttle: Optional[Callable[[float], None]] = None,
+    ) -> None:
+        if qps <= 0:
+            raise ValueError("qps must be greater than zero")
+        if burst < 1:
+            raise ValueError("burst must be at least one")
+        self.qps = qps
+        self.burst = burst
+        self.on_throttle = on_throttle
+        self.throttled = 0
+        self.throttled_seconds = 0.0
+        self._tokens = float(burst)
+        self._updated = time.monotonic()
+        # Sync clients run each call in its own event loop thread
+        self._lock = threading.Lock()
+
+    def _reserve(self) -> float:
+        """Take a token and return how long to wait before it can be used."""
+        with self._lock:
+            now = time.monotonic()
+            self._tokens = min(
+                self.burst, self._tokens + (now - self._updated) * self.qps
+            )
+            self._updated = now
+            self._tokens -= 1
+            delay = max(-self._tokens / self.qps, 0.0)
+            if delay:
+                self.throttled += 1
+                self.throttled_seconds += delay
+            return delay
+
+    async def acquire(self) -> None:
+        """Wait until a request can be sent."""
+        delay = self._reserve()
+        if not delay:
+            return
+        if self.on_throttle is not None:
+            self.on_throttle(delay)
+        try:
+            await anyio.sleep(delay)
+        except anyio.get_cancelled_exc_class():
+            with self._lock:
+                self._tokens += 1
+            raise
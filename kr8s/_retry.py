@@ -0,0 +1,95 @@
+# SPDX-FileCopyrightText: Copyright (c) 2023, Dask Developers, NVIDIA
+# SPDX-License-Identifier: BSD 3-Clause License
+from __future__ import annotations
+
+import email.utils
+import random
+import time
+from dataclasses import dataclass
+from typing import FrozenSet, Optional, Tuple, Type
+
+import httpx
+
+# Errors raised before the request was written to the connection, so retrying is safe
+# even for requests which are not idempotent.
+NOT_SENT_ERRORS = (httpx.ConnectError, httpx.ConnectTimeout, httpx.PoolTimeout)
+
+
+@dataclass(frozen=True)
+class RetryPolicy:
+    """Control how requests to the Kubernetes API are retried on transient errors.
+
+    Idempotent requests, ``GET``, ``HEAD`` and ``OPTIONS`` by default, are retried when
+    the server responds with one of ``status_codes`` or the request fails with one of
+    ``exceptions``. Other requests are only retried if the connection could not be made,
+    because otherwise the server may have already acted on them.
+
+    When a ``429`` or ``503`` response includes a ``Retry-After`` header it is used as
+    the delay before the next attempt, otherwise the delay increases exponentially from
+    ``backoff_base`` with some jitter. Delays are capped at ``backoff_max``.
+
+    Pass a policy to :func:`kr8s.api` with the ``retry`` keyword, or ``retry=False`` to
+    disable retries.
+
+    Args:
+        ``max_attempts`` (int): Total number of attempts including the first one.
+
+        ``backoff_base`` (float): Delay in seconds before the first retry.
+
+        ``backoff_max`` (float): Maximum delay in seconds between attempts.
+
+        ``status_codes`` (frozenset): Response status codes to retry.
+
+        ``exceptions`` (tuple): Exception classes to retry.
+
+        ``methods`` (frozenset): HTTP methods which are safe to retry.
+    """
+
+    max_attempts: int = 5
+    backoff_base: float = 0.5
+    backoff_max: float = 30.0
+    status_codes: FrozenSet[int] = frozenset({429, 500, 502, 503, 504})
+    exceptions: Tuple[Type[Exception], ...] = (httpx.TransportError,)
+    methods: FrozenSet[str] = frozenset({"GET", "HEAD", "OPTIONS"})
+
+    def is_idempotent(self, method: str) -> bool:
+        return method.upper() in self.methods
+
+    def should_retry_response(self, method: str, response: httpx.Response) -> bool:
+        """Whether a request which got this response should be sent again."""
+        return self.is_idempotent(method) and response.status_code in self.status_codes
+
+    def should_retry_exception(self, method: str, error: Exception) -> bool:
+        """Whether a request which raised this error should be sent again."""
+        if isinstance(error, NOT_SENT_ERRORS):
+            return True
+        return self.is_idempotent(method) and isinstance(error, self.exceptions)
+
+    def delay(self, attempt: int, response: Optional[httpx.Response] = None) -> float:
+        """Seconds to wait before the next attempt.
+
+        Args:
+            attempt: The number of attempts made so far.
+            response: The response to the last attempt, if there was one.
+        """
+        if response is not None and response.status_code in (429, 503):
+            retry_after = _parse_retry_after(response.headers.get("Retry-After"))
+            if retry_after is not None:
+                return min(retry_after, self.backoff_max)
+        delay = min(self.backoff_base * 2 ** (attempt - 1), self.backoff_max)
+        return random.uniform(delay / 2, delay)
+
+
+def _parse_retry_after(value: Optional[str]) -> Optional[float]:
+    """Parse a ``Retry-After`` header which is either seconds or an HTTP date."""
+    if not value:
+        return None
+    try:
+        return max(float(value), 0.0)
+    except ValueError:
+        pass
+    try:
+        retry_at = email.utils.parsedate_to_datetime(value)
+    except (TypeError, ValueError):
+        return None
+    return max(retry_at.timestamp() - time.time(), 0.0)
@@ -0,0 +1,406 @@
+# SPDX-FileCopyrightText: Copyright (c) 2023, Dask Developers, NVIDIA
+# SPDX-License-Identifier: BSD 3-Clause License
+"""Builders for Kubernetes label and field selectors."""
+from __future__ import annotations
+
+import re
+from typing import Any, Dict, List, Tuple
+
+# https://kubernetes.io/docs/concepts/overview/working-with-objects/labels/#syntax-and-character-set
+QUALIFIED_NAME = re.compile(r"^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$")
+DNS_SUBDOMAIN = re.compile(
+    r"^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$"
+)
+FIELD_KEY = re.compile(r"^[A-Za-z0-9_.\-/\[\]]+$")
+
+LABEL_KEY_PATTERN = r"[^\s!=,()]+"
+SET_TERM = re.compile(
+    rf"^(?P<key>{LABEL_KEY_PATTERN})\s+(?P<op>in|notin)\s*\((?P<values>[^()]*)\)$"
+)
+NOT_EXISTS_TERM = re.compile(rf"^!\s*(?P<key>{LABEL_KEY_PATTERN})$")
+EQUALITY_TERM = re.compile(
+    rf"^(?P<key>{LABEL_KEY_PATTERN})\s*(?P<op>==|!=|=)\s*(?P<value>[^\s!=,()]*)$"
+)
+EXISTS_TERM = re.compile(rf"^(?P<key>{LABEL_KEY_PATTERN})$")
+FIELD_TERM = re.compile(r"^(?P<key>[^!=]+?)\s*(?P<op>!=|==|=)(?P<value>.*)$")
+
+Requirement = Tuple[str, str, Tuple[str, ...]]
+
+
+def _validate_label_key(key: str) -> None:
+    prefix, slash, name = key.rpartition("/")
+    if not name or len(name) > 63 or not QUALIFIED_NAME.match(name):
+        raise ValueError(f"Invalid label key {key!r}")
+    if slash and (not prefix or len(prefix) > 253 or not DNS_SUBDOMAIN.match(prefix)):
+        raise ValueError(f"Invalid label key prefix {key!r}")
+
+
+def _validate_label_value(value: str) -> None:
+    if not isinstance(value, str):
+        raise TypeError(f"Label values must be strings, got {value!r}")
+    if value and (len(value) > 63 or not QUALIFIED_NAME.match(value)):
+        raise ValueError(f"Invalid label value {value!r}")
+
+
+def _validate_field_key(key: str) -> None:
+    if not FIELD_KEY.match(key):
+        raise ValueError(f"Invalid field selector key {key!r}")
+
+
+def escape_field_value(value: str) -> str:
+    """Escape a value for use in a field selector.
+
+    Parameters
+    ----------
+    value : str
+        The raw value.
+
+    Returns
+    -------
+    str
+        The value with ``\\``, ``,`` and ``=`` escaped with a backslash.
+    """
+    return value.replace("\\", "\\\\").replace(",", "\\,").replace("=", "\\=")
+
+
+def unescape_field_value(value: str) -> str:
+    """Reverse :func:`escape_field_value`.
+
+    Parameters
+    ----------
+    value : str
+        The escaped value.
+
+    Returns
+    -------
+    str
+        The raw value.
+
+    Raises
+    ------
+    ValueError
+        If the value contains an invalid escape sequence or an unescaped ``,`` or ``=``.
+    """
+    result = []
+    escaped = False
+    for char in value:
+        if escaped:
+            if char not in "\\,=":
+                raise ValueError(f"Invalid escape sequence '\\{char}' in {value!r}")
+            result.append(char)
+            escaped = False
+        elif char == "\\":
+            escaped = True
+        elif char in ",=":
+            raise ValueError(f"Unescaped {char!r} in field selector value {value!r}")
+        else:
+            result.append(char)
+    if escaped:
+        raise ValueError(f"Trailing backslash in field selector value {value!r}")
+    return "".join(result)
+
+
+def _split_label_terms(selector: str) -> List[str]:
+    """Split a label selector on commas which are not inside a set of values."""
+    terms, current, depth = [], [], 0
+    for char in selector:
+        if char == "(":
+            depth += 1
+        elif char == ")":
+            depth -= 1
+        if char == "," and depth == 0:
+            terms.append("".join(current))
+            current = []
+        else:
+            current.append(char)
+    terms.append("".join(current))
+    return [term.strip() for term in terms if term.strip()]
+
+
+def _split_field_terms(selector: str) -> List[str]:
+    """Split a field selector on commas which are not escaped."""
+    terms, current, escaped = [], [], False
+    for char in selector:
+        if escaped:
+            escaped = False
+        elif char == "\\":
+            escaped = True
+        elif char == ",":
+            terms.append("".join(current))
+            current = []
+            continue
+        current.append(char)
+    terms.append("".join(current))
+    return [term.strip() for term in terms if term.strip()]
+
+
+class LabelSelector:
+    """Build a Kubernetes label selector.
+
+    Every method returns a new selector so they can be chained, and selectors can be
+    combined with ``&``. Keys and values are validated as they are added so mistakes
+    are caught before a request is made.
+
+    Selectors can be passed anywhere kr8s accepts a ``label_selector``.
+
+    Examples
+    --------
+    >>> from kr8s import LabelSelector
+    >>> selector = (
+    ...     LabelSelector()
+    ...     .equals("app", "nginx")
+    ...     .in_("tier", "web", "api")
+    ...     .not_exists("deprecated")
+    ... )
+    >>> str(selector)
+    'app=nginx,tier in (web,api),!deprecated'
+    >>> pods = kr8s.get("pods", label_selector=selector)
+    """
+
+    def __init__(self, requirements: Tuple[Requirement, ...] = ()) -> None:
+        self._requirements = tuple(requirements)
+
+    @classmethod
+    def from_dict(cls, labels: Dict[str, Any]) -> LabelSelector:
+        """Create a selector matching all of the given labels.
+
+        Values which aren't strings are converted with ``str()``, so dictionaries like
+        ``{"replicas": 3}`` can be passed as selectors.
+        """
+        selector = cls()
+        for key, value in labels.items():
+            selector = selector.equals(key, str(value))
+        return selector
+
+    @classmethod
+    def from_spec(cls, spec: Dict) -> LabelSelector:
+        """Create a selector from a ``LabelSelector`` in an object spec.
+
+        Supports both ``matchLabels`` and ``matchExpressions``, as found in the
+        ``spec.selector`` of resources like Deployments and ReplicaSets.
+        """
+        selector = cls.from_dict(spec.get("matchLabels") or {})
+        for expression in spec.get("matchExpressions") or []:
+            key, operator = expression["key"], expression["operator"]
+            values = expression.get("values") or []
+            if operator == "In":
+                selector = selector.in_(key, *values)
+            elif operator == "NotIn":
+                selector = selector.not_in(key, *values)
+            elif operator == "Exists":
+                selector = selector.exists(key)
+            elif operator == "DoesNotExist":
+                selector = selector.not_exists(key)
+            else:
+                raise ValueError(f"Unknown label selector operator {operator!r}")
+        return selector
+
+    @classmethod
+    def parse(cls, selector: str) -> LabelSelector:
+        """Parse a label selector string.
+
+        Parameters
+        ----------
+        selector : str
+            A label selector such as ``"app=nginx,tier in (web,api)"``.
+
+        Returns
+        -------
+        LabelSelector
+            The parsed selector.
+
+        Raises
+        ------
+        ValueError
+            If the selector is not valid.
+        """
+        result = cls()
+        for term in _split_label_terms(selector):
+            if match := SET_TERM.match(term):
+                values = [v.strip() for v in match["values"].split(",") if v.strip()]
+                if match["op"] == "in":
+                    result = result.in_(match["key"], *values)
+                else:
+                    result = result.not_in(match["key"], *values)
+            elif match := NOT_EXISTS_TERM.match(term):
+                result = result.not_exists(match["key"])
+            elif match := EQUALITY_TERM.match(term):
+                if match["op"] == "!=":
+                    result = result.not_equals(match["key"], match["value"])
+                else:
+                    result = result.equals(match["key"], match["value"])
+            elif match := EXISTS_TERM.match(term):
+                result = result.exists(match["key"])
+            else:
+                raise ValueError(f"Unable to parse label selector term {term!r}")
+        return result
+
+    def _add(
+        self, key: str, operator: str, values: Tuple[str, ...] = ()
+    ) -> LabelSelector:
+        _validate_label_key(key)
+        for value in values:
+            _validate_label_value(value)
+        return type(self)(self._requirements + ((key, operator, tuple(values)),))
+
+    def equals(self, key: str, value: str) -> LabelSelector:
+        """Match objects where the label ``key`` is ``value``."""
+        return self._add(key, "=", (value,))
+
+    def not_equals(self, key: str, value: str) -> LabelSelector:
+        """Match objects where the label ``key`` is not ``value`` or is not set."""
+        return self._add(key, "!=", (value,))
+
+    def in_(self, key: str, *values: str) -> LabelSelector:
+        """Match objects where the label ``key`` is one of ``values``."""
+        if not values:
+            raise ValueError(f"At least one value is required for {key!r} in (...)")
+        return self._add(key, "in", values)
+
+    def not_in(self, key: str, *values: str) -> LabelSelector:
+        """Match objects where the label ``key`` is not any of ``values``."""
+        if not values:
+            raise ValueError(f"At least one value is required for {key!r} notin (...)")
+        return self._add(key, "notin", values)
+
+    def exists(self, key: str) -> LabelSelector:
+        """Match objects which have the label ``key``."""
+        return self._add(key, "exists")
+
+    def not_exists(self, key: str) -> LabelSelector:
+        """Match objects which do not have the label ``key``."""
+        return self._add(key, "!")
+
+    def __and__(self, other: LabelSelector) -> LabelSelector:
+        if not isinstance(other, LabelSelector):
+            return NotImplemented
+        return type(self)(self._requirements + other._requirements)
+
+    def __str__(self) -> str:
+        terms = []
+        for key, operator, values in self._requirements:
+            if operator in ("=", "!="):
+                terms.append(f"{key}{operator}{values[0]}")
+            elif operator in ("in", "notin"):
+                terms.append(f"{key} {operator} ({','.join(values)})")
+            elif operator == "exists":
+                terms.append(key)
+            else:
+                terms.append(f"!{key}")
+        return ",".join(terms)
+
+    def __repr__(self) -> str:
+        return f"LabelSelector({str(self)!r})"
+
+    def __bool__(self) -> bool:
+        return bool(self._requirements)
+
+    def __eq__(self, other: object) -> bool:
+        if not isinstance(other, LabelSelector):
+            return NotImplemented
+        return self._requirements == other._requirements
+
+    def __hash__(self) -> int:
+        return hash(self._requirements)
+
+
+class FieldSelector:
+    """Build a Kubernetes field selector.
+
+    Every method returns a new selector so they can be chained, and selectors can be
+    combined with ``&``. Values are escaped when the selector is serialized.
+
+    Selectors can be passed anywhere kr8s accepts a ``field_selector``.
+
+    Examples
+    --------
+    >>> from kr8s import FieldSelector
+    >>> selector = FieldSelector().equals("status.phase", "Running")
+    >>> str(selector)
+    'status.phase=Running'
+    >>> pods = kr8s.get("pods", field_selector=selector)
+    """
+
+    def __init__(self, requirements: Tuple[Requirement, ...] = ()) -> None:
+        self._requirements = tuple(requirements)
+
+    @classmethod
+    def from_dict(cls, fields: Dict[str, Any]) -> FieldSelector:
+        """Create a selector matching all of the given fields.
+
+        Values which aren't strings are converted with ``str()``.
+        """
+        selector = cls()
+        for key, value in fields.items():
+            selector = selector.equals(key, str(value))
+        return selector
+
+    @classmethod
+    def parse(cls, selector: str) -> FieldSelector:
+        """Parse a field selector string.
+
+        Parameters
+        ----------
+        selector : str
+            A field selector such as ``"status.phase=Running,spec.nodeName!=foo"``.
+
+        Returns
+        -------
+        FieldSelector
+            The parsed selector.
+
+        Raises
+        ------
+        ValueError
+            If the selector is not valid.
+        """
+        result = cls()
+        for term in _split_field_terms(selector):
+            match = FIELD_TERM.match(term)
+            if not match:
+                raise ValueError(f"Unable to parse field selector term {term!r}")
+            value = unescape_field_value(match["value"])
+            if match["op"] == "!=":
+                result = result.not_equals(match["key"], value)
+            else:
+                result = result.equals(match["key"], value)
+        return result
+
+    def _add(self, key: str, operator: str, value: str) -> FieldSelector:
+        _validate_field_key(key)
+        if not isinstance(value, str):
+            raise TypeError(f"Field values must be strings, got {value!r}")
+        return type(self)(self._requirements + ((key, operator, (value,)),))
+
+    def equals(self, key: str, value: str) -> FieldSelector:
+        """Match objects where the field ``key`` is ``value``."""
+        return self._add(key, "=", value)
+
+    def not_equals(self, key: str, value: str) -> FieldSelector:
+        """Match objects where the field ``key`` is not ``value``."""
+        return self._add(key, "!=", value)
+
+    def __and__(self, other: FieldSelector) -> FieldSelector:
+        if not isinstance(other, FieldSelector):
+            return NotImplemented
+        return type(self)(self._requirements + other._requirements)
+
+    def __str__(self) -> str:
+        return ",".join(
+            f"{key}{operator}{escape_field_value(values[0])}"
+            for key, operator, values in self._requirements
+        )
+
+    def __repr__(self) -> str:
+        return f"FieldSelector({str(self)!r})"
+
+    def __bool__(self) -> bool:
+        return bool(self._requirements)
+
+    def __eq__(self, other: object) -> bool:
+        if not isinstance(other, FieldSelector):
+            return NotImplemented
+        return self._requirements == other._requirements
+
+    def __hash__(self) -> int:
+        return hash(self._requirements)
@@ -0,0 +1,103 @@
+# SPDX-FileCopyrightText: Copyright (c) 2023, Dask Developers, NVIDIA
+# SPDX-License-Identifier: BSD 3-Clause License
+from __future__ import annotations
+
+import inspect
+import re
+import sys
+import threading
+from typing import Callable, List, NamedTuple
+
+# Warning headers look like 299 - "unknown field \"spec.foo\""
+WARNING_HEADER_PATTERN = re.compile(r'^(\d{3}) (\S+) "((?:[^"\\]|\\.)*)"')
+
+
+class ServerWarning(NamedTuple):
+    """A warning sent by the Kubernetes API in a ``Warning`` response header."""
+
+    code: int
+    agent: str
+    text: str
+
+
+def parse_warning(header: str) -> ServerWarning:
+    """Parse a ``Warning`` response header.
+
+    Malformed headers are passed on as they are, with the ``299`` code and ``-`` agent
+    used by the Kubernetes API.
+    """
+    match = WARNING_HEADER_PATTERN.match(header)
+    if not match:
+        return ServerWarning(299, "-", header)
+    code, agent, text = match.groups()
+    return ServerWarning(int(code), agent, re.sub(r"\\(.)", r"\1", text))
+
+
+def adapt_warning_handler(handler: Callable) -> Callable[[int, str, str], None]:
+    """Call a handler which takes a single argument with only the text of warnings.
+
+    Handlers used to be called with just the text, so functions like ``logger.warning``
+    or ``list.append`` keep working.
+    """
+    try:
+        parameters = inspect.signature(handler).parameters.values()
+    except (TypeError, ValueError):
+        return handler
+    required = [
+        p
+        for p in parameters
+        if p.kind in (p.POSITIONAL_ONLY, p.POSITIONAL_OR_KEYWORD)
+        and p.default is p.empty
+    ]
+    if len(required) == 1:
+        return lambda code, agent, text: handler(text)
+    return handler
+
+
+class LogOnceWarningHandler:
+    """Print each unique warning from the server to stderr once, like kubectl.
+
+    This is the default ``warning_handler`` of :func:`kr8s.api`.
+    """
+
+    def __init__(self) -> None:
+        self._seen = set()
+        # Sync clients run each call in its own event loop thread
+        self._lock = threading.Lock()
+
+    def __call__(self, code: int, agent: str, text: str) -> None:
+        with self._lock:
+            if text in self._seen:
+                return
+            self._seen.add(text)
+        print(f"Warning: {text}", file=sys.stderr)
+
+
+class WarningCollector:
+    """Collect the warnings sent by the server, for example to check them in tests.
+
+    Attributes:
+        ``warnings`` (list): A :class:`ServerWarning` for each warning received, in
+        order and including duplicates.
+
+    Example:
+        >>> collector = kr8s.WarningCollector()
+        >>> api = kr8s.api(warning_handler=collector)
+        >>> api.get("ingresses.v1beta1.extensions")
+        >>> print(collector.texts)
+    """
+
+    def __init__(self) -> None:
+        self.warnings: List[ServerWarning] = []
+
+    def __call__(self, code: int, agent: str, text: str) -> None:
+        self.warnings.append(ServerWarning(code, agent, text))
+
+    @property
+    def texts(self) -> List[str]:
+        """The text of each warning."""
+        return [warning.text for warning in self.warnings]
+
+    def clear(self) -> None:
+        """Forget the warnings collected so far."""
+        self.warnings.clear()
@@ -1,6 +1,7 @@
 # SPDX-FileCopyrightText: Copyright (c) 2023, Dask Developers, NVIDIA
 # SPDX-License-Identifier: BSD 3-Clause License
 from kr8s._api import Api  # noqa
+from kr8s._auth import load_kubeconfig  # noqa
 
 from ._api import api  # noqa
 from ._helpers import api_resources, get, version, watch  # noqa
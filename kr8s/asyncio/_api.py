@@ -1,6 +1,12 @@
 # SPDX-FileCopyrightText: Copyright (c) 2023, Dask Developers, NVIDIA
 # SPDX-License-Identifier: BSD 3-Clause License
+from typing import Callable, Optional, Union
+
+import httpx
+
 from kr8s._api import Api as _AsyncApi
+from kr8s._ratelimit import RateLimiter
+from kr8s._retry import RetryPolicy
 
 
 async def api(
@@ -8,11 +14,62 @@ async def api(
     kubeconfig: str = None,
     serviceaccount: str = None,
     namespace: str = None,
+    retry: Union[RetryPolicy, bool] = None,
+    rate_limit: Union[RateLimiter, bool] = None,
+    proxy: Union[str, bool, Callable[[str], Optional[str]]] = None,
+    transport: Union[httpx.AsyncBaseTransport, Callable] = None,
+    compression: bool = None,
+    trust_system_ca: bool = None,
+    timeout: Union[float, bool] = None,
+    context: str = None,
+    warning_handler: Union[
+        Callable[[int, str, str], None], Callable[[str], None], bool
+    ] = None,
     _asyncio: bool = True,
 ) -> _AsyncApi:
     """Create a :class:`kr8s.Api` object for interacting with the Kubernetes API.
 
     If a kr8s object already exists with the same arguments, it will be returned.
+
+    The ``current-context`` of the kubeconfig is used unless a ``context`` is given.
+    Each context gets its own cached client, so this can be used to work with several
+    clusters at once.
+
+    Transient errors are retried according to ``retry``, which defaults to
+    :class:`kr8s.RetryPolicy`. Pass ``retry=False`` to disable retries.
+
+    Requests are rate limited on the client by ``rate_limit``, which defaults to a
+    :class:`kr8s.RateLimiter` of 5 requests per second with a burst of 10. Pass
+    ``rate_limit=False`` to disable it.
+
+    Requests use the ``proxy-url`` of the kubeconfig cluster if set, otherwise the
+    ``HTTPS_PROXY``, ``HTTP_PROXY`` and ``NO_PROXY`` environment variables. Pass a
+    ``proxy`` URL to override them, ``proxy=False`` to never use a proxy, or a function
+    which takes the server URL and returns the proxy URL to use, or ``None``.
+
+    A custom ``transport`` can be given to send requests, for example to record them
+    in tests, or a function which wraps the default transport to add middleware.
+
+    Responses are requested with gzip compression, which the API server uses for large
+    responses like lists. Pass ``compression=False`` to disable it, for example if a
+    proxy mishandles compressed responses.
+
+    The server certificate is verified with the certificate authority from the
+    kubeconfig or service account, or the system certificates if there isn't one. Pass
+    ``trust_system_ca=True`` to trust both, for example when the API server is behind a
+    proxy with a publicly trusted certificate.
+
+    Requests time out after ``timeout`` seconds, 5 by default, and raise a
+    :class:`kr8s.RequestTimeoutError`. Pass ``timeout=False`` to wait forever. Long
+    running requests such as watches, followed logs, exec and port forwards only use
+    the timeout while connecting.
+
+    Warnings sent by the server, for example about deprecated APIs or unknown fields
+    when writing with ``field_validation="Warn"``, are printed to stderr once each like
+    kubectl. Pass a ``warning_handler`` function to handle them yourself, it is called
+    with the ``code``, ``agent`` and ``text`` of every warning, for example a
+    :class:`kr8s.WarningCollector`, or with just the ``text`` if it only takes one
+    argument, like ``logger.warning``. Pass ``warning_handler=False`` to ignore them.
     """
 
     from kr8s import Api as _SyncApi
@@ -35,4 +92,13 @@ async def api(
         kubeconfig=kubeconfig,
         serviceaccount=serviceaccount,
         namespace=namespace,
+        retry=retry,
+        rate_limit=rate_limit,
+        proxy=proxy,
+        transport=transport,
+        compression=compression,
+        trust_system_ca=trust_system_ca,
+        timeout=timeout,
+        context=context,
+        warning_handler=warning_handler,
     )
@@ -3,6 +3,7 @@
 from typing import Dict, List, Union
 
 from kr8s._api import Api
+from kr8s._selectors import FieldSelector, LabelSelector
 
 from ._api import api as _api
 
@@ -11,8 +12,8 @@ async def get(
     kind: str,
     *names: List[str],
     namespace: str = None,
-    label_selector: Union[str, Dict] = None,
-    field_selector: Union[str, Dict] = None,
+    label_selector: Union[str, Dict, LabelSelector] = None,
+    field_selector: Union[str, Dict, FieldSelector] = None,
     as_object: object = None,
     api=None,
     _asyncio=True,
@@ -40,9 +41,11 @@ async def version(api=None, _asyncio=True):
 async def watch(
     kind: str,
     namespace: str = None,
-    label_selector: Union[str, Dict] = None,
-    field_selector: Union[str, Dict] = None,
+    label_selector: Union[str, Dict, LabelSelector] = None,
+    field_selector: Union[str, Dict, FieldSelector] = None,
     since: str = None,
+    allow_bookmarks: bool = False,
+    reconnect: bool = False,
     api=None,
     _asyncio=True,
 ):
@@ -54,6 +57,8 @@ async def watch(
         label_selector=label_selector,
         field_selector=field_selector,
         since=since,
+        allow_bookmarks=allow_bookmarks,
+        reconnect=reconnect,
     ):
         yield (t, o)
 
@@ -22,10 +22,13 @@ from kr8s._objects import (  # noqa
     Namespace,
     NetworkPolicy,
     Node,
+    NodeMetrics,
+    PartialObjectMetadata,
     PersistentVolume,
     PersistentVolumeClaim,
     Pod,
     PodDisruptionBudget,
+    PodMetrics,
     PodTemplate,
     ReplicaSet,
     ReplicationController,
@@ -39,4 +42,6 @@ from kr8s._objects import (  # noqa
     Table,
     object_from_name_type,
     objects_from_files,
+    objects_from_json,
+    objects_from_yaml,
 )
@@ -1,3 +1,5 @@
+from functools import partial, update_wrapper
+
 from ._io import run_sync, sync
 from ._objects import (
     APIObject as _APIObject,
@@ -62,6 +64,12 @@ from ._objects import (
 from ._objects import (
     Node as _Node,
 )
+from ._objects import (
+    NodeMetrics as _NodeMetrics,
+)
+from ._objects import (
+    PartialObjectMetadata as _PartialObjectMetadata,
+)
 from ._objects import (
     PersistentVolume as _PersistentVolume,
 )
@@ -74,6 +82,9 @@ from ._objects import (
 from ._objects import (
     PodDisruptionBudget as _PodDisruptionBudget,
 )
+from ._objects import (
+    PodMetrics as _PodMetrics,
+)
 from ._objects import (
     PodTemplate as _PodTemplate,
 )
@@ -110,6 +121,8 @@ from ._objects import (
 from ._objects import get_class, new_class, object_from_spec  # noqa
 from ._objects import object_from_name_type as _object_from_name_type
 from ._objects import objects_from_files as _objects_from_files
+from ._objects import objects_from_json as _objects_from_json
+from ._objects import objects_from_yaml as _objects_from_yaml
 
 
 @sync
@@ -322,6 +335,24 @@ class CustomResourceDefinition(_CustomResourceDefinition):
     _asyncio = False
 
 
+@sync
+class NodeMetrics(_NodeMetrics):
+    __doc__ = _NodeMetrics.__doc__
+    _asyncio = False
+
+
+@sync
+class PodMetrics(_PodMetrics):
+    __doc__ = _PodMetrics.__doc__
+    _asyncio = False
+
+
+@sync
+class PartialObjectMetadata(_PartialObjectMetadata):
+    __doc__ = _PartialObjectMetadata.__doc__
+    _asyncio = False
+
+
 @sync
 class Table(_Table):
     __doc__ = _Table.__doc__
@@ -330,3 +361,8 @@ class Table(_Table):
 
 object_from_name_type = run_sync(_object_from_name_type)
 objects_from_files = run_sync(_objects_from_files)
+
+objects_from_yaml = partial(_objects_from_yaml, _asyncio=False)
+update_wrapper(objects_from_yaml, _objects_from_yaml)
+objects_from_json = partial(_objects_from_json, _asyncio=False)
+update_wrapper(objects_from_json, _objects_from_json)
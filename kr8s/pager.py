@@ -0,0 +1,7 @@
+from ._io import sync
+from ._pager import ListPager as _ListPager
+
+
+@sync
+class ListPager(_ListPager):
+    __doc__ = _ListPager.__doc__
@@ -2,21 +2,39 @@
 # SPDX-FileCopyrightText: Copyright (c) 2023, Dask Developers, NVIDIA
 # SPDX-License-Identifier: BSD 3-Clause License
 #
-# Produce a valid client.authentication.k8s.io/v1beta1 ExecCredential from
+# Produce a valid client.authentication.k8s.io ExecCredential from
 # environment variables.
+#
+# The apiVersion is taken from KUBERNETES_EXEC_INFO and the base64 encoded
+# kubeconfig certificate data is converted to PEM. If KUBE_EXEC_COUNT_FILE is
+# set a line is appended to it each time the plugin is run and if
+# KUBE_EXEC_EXPIRATION is set it is used as the expirationTimestamp.
 
+import base64
 import json
 import os
 
+exec_info = json.loads(os.environ["KUBERNETES_EXEC_INFO"])
+
+if "KUBE_EXEC_COUNT_FILE" in os.environ:
+    with open(os.environ["KUBE_EXEC_COUNT_FILE"], "a") as f:
+        f.write(json.dumps(exec_info) + "\n")
+
+status = {
+    "clientCertificateData": base64.b64decode(
+        os.environ["KUBE_CLIENT_CERTIFICATE_DATA"]
+    ).decode(),
+    "clientKeyData": base64.b64decode(os.environ["KUBE_CLIENT_KEY_DATA"]).decode(),
+}
+if "KUBE_EXEC_EXPIRATION" in os.environ:
+    status["expirationTimestamp"] = os.environ["KUBE_EXEC_EXPIRATION"]
+
 print(
     json.dumps(
         {
-            "apiVersion": "client.authentication.k8s.io/v1beta1",
+            "apiVersion": exec_info["apiVersion"],
             "kind": "ExecCredential",
-            "status": {
-                "clientCertificateData": os.environ["KUBE_CLIENT_CERTIFICATE_DATA"],
-                "clientKeyData": os.environ["KUBE_CLIENT_KEY_DATA"],
-            },
+            "status": status,
         }
     )
 )
@@ -1,12 +1,19 @@
 # SPDX-FileCopyrightText: Copyright (c) 2023 NVIDIA
 # SPDX-License-Identifier: BSD 3-Clause License
 import asyncio
+import gzip
+import json
+import logging
+from decimal import Decimal
 
+import httpx
 import pytest
+import yaml
 
 import kr8s
 import kr8s.asyncio
-from kr8s.asyncio.objects import Pod, Table
+from kr8s._api import _parse_version
+from kr8s.asyncio.objects import ConfigMap, PartialObjectMetadata, Pod, Table
 
 
 async def test_factory_bypass():
@@ -69,6 +76,33 @@ async def test_version():
     assert "major" in version
 
 
+async def test_version_cached():
+    kubernetes = await kr8s.asyncio.api()
+    version = await kubernetes.version()
+    assert await kubernetes.version() is version
+    assert await kubernetes.version(refresh=True) == version
+
+    major, minor, patch = await kubernetes.version_info()
+    assert (major, minor) == (int(version["major"]), int(version["minor"].rstrip("+")))
+    assert await kubernetes.version_info() >= (1, 0)
+
+
+def test_parse_version():
+    assert _parse_version({"gitVersion": "v1.28.3+k3s1"}) == (1, 28, 3)
+    assert _parse_version({"major": "1", "minor": "27+"}) == (1, 27, 0)
+
+
+async def test_openapi_schema():
+    kubernetes = await kr8s.asyncio.api()
+    index = await kubernetes.openapi_schema()
+    assert "apis/apps/v1" in index["paths"]
+    schema = await kubernetes.openapi_schema("apps/v1")
+    assert "io.k8s.api.apps.v1.Deployment" in schema["components"]["schemas"]
+    assert await kubernetes.openapi_schema("apps/v1") is schema
+    with pytest.raises(KeyError):
+        await kubernetes.openapi_schema("foo.example.com/v1")
+
+
 def test_helper_version():
     version = kr8s.version()
     assert "major" in version
@@ -99,6 +133,246 @@ async def test_get_pods(namespace):
     assert isinstance(pods[0], Pod)
 
 
+async def test_get_all_namespaces(example_pod_spec, ns):
+    pod = await Pod(example_pod_spec)
+    await pod.create()
+    kubernetes = await kr8s.asyncio.api()
+    assert kubernetes._construct_url("v1", namespace=None, url="pods") == "/api/v1/pods"
+    pods = await kubernetes.get(
+        "pods",
+        namespace=kr8s.ALL,
+        label_selector=pod.labels,
+        field_selector={"metadata.name": pod.name},
+        limit=1,
+    )
+    assert [(p.namespace, p.name) for p in pods] == [(ns, pod.name)]
+    namespaces = {p.namespace for p in await kubernetes.get("pods", namespace=kr8s.ALL)}
+    assert {ns, "kube-system"} <= namespaces
+    # Cluster scoped resources ignore the namespace
+    assert await kubernetes.get("nodes", namespace=kr8s.ALL)
+    await pod.delete()
+
+
+async def test_get_pods_with_selector_builders(example_pod_spec, ns):
+    pod = await Pod(example_pod_spec)
+    await pod.create()
+    pods = await kr8s.asyncio.get(
+        "pods",
+        namespace=ns,
+        label_selector=kr8s.LabelSelector.from_dict(pod.labels),
+        field_selector=kr8s.FieldSelector().equals("metadata.name", pod.name),
+    )
+    assert [p.name for p in pods] == [pod.name]
+    pods = await kr8s.asyncio.get(
+        "pods",
+        namespace=ns,
+        label_selector=kr8s.LabelSelector().not_exists("hello"),
+        field_selector=kr8s.FieldSelector().equals("metadata.name", pod.name),
+    )
+    assert pods == []
+    await pod.delete()
+
+
+async def test_get_pods_paginated():
+    pods = await kr8s.asyncio.get("pods", namespace=kr8s.ALL)
+    paginated = await kr8s.asyncio.get("pods", namespace=kr8s.ALL, limit=2)
+    assert len(pods) > 2
+    assert {p.name for p in paginated} == {p.name for p in pods}
+
+
+async def test_pager():
+    kubernetes = await kr8s.asyncio.api()
+    pager = kubernetes.pager("pods", namespace=kr8s.ALL, limit=1)
+    pods, more = await pager.next()
+    assert len(pods) == 1
+    assert more
+    assert pager.continue_token
+    remaining = [pod async for page in pager.pages() for pod in page]
+    assert remaining
+    assert pager.done
+    assert pager.continue_token is None
+    assert pager.resource_version
+    assert await pager.next() == ([], False)
+
+
+async def test_get_metadata_only(example_pod_spec, ns):
+    pod = await Pod(example_pod_spec)
+    await pod.create()
+    pods = await kr8s.asyncio.get("pods", namespace=ns, metadata_only=True)
+    [partial] = [p for p in pods if p.name == pod.name]
+    assert isinstance(partial, PartialObjectMetadata)
+    assert partial.namespace == ns
+    assert partial.labels == pod.labels
+    assert "spec" not in partial.raw
+
+    paginated = await kr8s.asyncio.get(
+        "pods", namespace=ns, limit=1, metadata_only=True, strip_managed_fields=True
+    )
+    assert {p.name for p in paginated} == {p.name for p in pods}
+    assert all("managedFields" not in p.raw["metadata"] for p in paginated)
+    await pod.delete()
+
+
+def test_pager_sync():
+    kubernetes = kr8s.api()
+    pager = kubernetes.pager("pods", namespace=kr8s.ALL, limit=1)
+    pods = [pod for page in pager.pages() for pod in page]
+    assert len(pods) > 1
+    assert pods[0]._asyncio is False
+
+
+async def test_informer(example_pod_spec, ns):
+    kubernetes = await kr8s.asyncio.api()
+    events = []
+    informer = kubernetes.informer("pods", namespace=ns)
+    informer.add_event_handler(
+        on_add=lambda pod: events.append(("add", pod.name)),
+        on_update=lambda old, new: events.append(("update", new.name)),
+        on_delete=lambda pod: events.append(("delete", pod.name)),
+    )
+    assert not informer.has_synced
+    async with informer:
+        assert informer.has_synced
+        pod = await Pod(example_pod_spec)
+        await pod.create()
+        while informer.get(pod.name) is None:
+            await asyncio.sleep(0.1)
+        assert informer.get(pod.name, namespace=ns).name == pod.name
+        assert pod.name in [p.name for p in informer.list()]
+        await pod.delete()
+        while ("delete", pod.name) not in events:
+            await asyncio.sleep(0.1)
+        assert informer.get(pod.name) is None
+    assert events[0] == ("add", pod.name)
+
+    with pytest.raises(NotImplementedError):
+        kr8s.api().informer("pods")
+
+
+async def test_informer_relist():
+    lists = []
+
+    def pod(name, version):
+        return {
+            "metadata": {
+                "name": name,
+                "namespace": "default",
+                "resourceVersion": version,
+            }
+        }
+
+    def handler(request):
+        if request.url.params.get("watch") != "true":
+            # The second list is after the watch expired, with changes made meanwhile
+            lists.append(request)
+            if len(lists) == 1:
+                items, version = [pod("a", "1"), pod("b", "1")], "10"
+            else:
+                items, version = [pod("a", "2"), pod("c", "1")], "20"
+            return httpx.Response(
+                200, json={"metadata": {"resourceVersion": version}, "items": items}
+            )
+        if request.url.params["resourceVersion"] == "10":
+            status = {"kind": "Status", "code": 410, "reason": "Expired"}
+            event = {"type": "ERROR", "object": status}
+        elif request.url.params["resourceVersion"] == "20":
+            event = {"type": "MODIFIED", "object": pod("c", "21")}
+        else:
+            return httpx.Response(200, content=b"")
+        return httpx.Response(200, content=json.dumps(event).encode() + b"\n")
+
+    kubernetes = await kr8s.asyncio.api(
+        url="http://kr8s.test", transport=httpx.MockTransport(handler)
+    )
+    events = []
+    informer = kubernetes.informer("pods", namespace="default")
+    informer.add_event_handler(
+        on_add=lambda pod: events.append(("add", pod.name)),
+        on_update=lambda old, new: events.append(
+            ("update", new.name, old.metadata.resourceVersion)
+        ),
+        on_delete=lambda pod: events.append(("delete", pod.name)),
+    )
+    async with informer:
+        while ("update", "c", "1") not in events:
+            await asyncio.sleep(0.01)
+    assert informer.relists == 1
+    assert len(lists) == 2
+    assert events == [
+        ("add", "a"),
+        ("add", "b"),
+        # Changes missed while the watch was expired are found by listing again
+        ("update", "a", "1"),
+        ("add", "c"),
+        ("delete", "b"),
+        ("update", "c", "1"),
+    ]
+    assert sorted(p.name for p in informer.list()) == ["a", "c"]
+    assert informer.get("c").metadata.resourceVersion == "21"
+
+
+async def test_create_or_update(ns):
+    kubernetes = await kr8s.asyncio.api()
+    spec = {
+        "apiVersion": "v1",
+        "kind": "ConfigMap",
+        "metadata": {"name": "kr8s-create-or-update", "namespace": ns},
+    }
+    calls = []
+
+    def mutate(config_map):
+        calls.append(config_map.raw.get("data"))
+        config_map.raw["data"] = {"count": str(len(calls))}
+
+    config_map, created = await kubernetes.create_or_update(spec, mutate)
+    assert created
+    assert config_map.raw["data"] == {"count": "1"}
+    config_map, created = await kubernetes.create_or_update(spec, mutate)
+    assert not created
+    assert calls == [None, {"count": "1"}]
+    assert config_map.raw["data"] == {"count": "2"}
+
+    existing, created = await kubernetes.get_or_create(
+        {**spec, "data": {"count": "ignored"}}
+    )
+    assert not created
+    assert existing.raw["data"] == {"count": "2"}
+    await config_map.delete()
+    spec["metadata"]["name"] = "kr8s-get-or-create"
+    config_map, created = await kubernetes.get_or_create(spec)
+    assert created
+    assert config_map.metadata.uid
+    await config_map.delete()
+
+
+async def test_get_all():
+    kubernetes = await kr8s.asyncio.api()
+    refs = [
+        {"kind": "Namespace", "name": "kube-system"},
+        {
+            "apiVersion": "apps/v1",
+            "kind": "Deployment",
+            "name": "coredns",
+            "namespace": "kube-system",
+        },
+        {"kind": "ConfigMap", "name": "kube-root-ca.crt", "namespace": "default"},
+    ]
+    namespace, deployment, config_map = await kubernetes.get_all(
+        refs, max_concurrency=2
+    )
+    assert namespace.kind == "Namespace"
+    assert deployment.namespace == "kube-system"
+    assert "ca.crt" in config_map.raw["data"]
+
+    with pytest.raises(kr8s.BatchGetError) as e:
+        await kubernetes.get_all(refs + [{"kind": "Pod", "name": "does-not-exist"}])
+    assert [r.name for r in e.value.results[:3]] == [r["name"] for r in refs]
+    assert e.value.results[3] is None
+    [(ref, error)] = e.value.errors
+    assert ref["name"] == "does-not-exist"
+    assert isinstance(error, kr8s.NotFoundError)
+
+
 async def test_get_pods_as_table():
     kubernetes = await kr8s.asyncio.api()
     pods = await kubernetes.get("pods", namespace="kube-system", as_object=Table)
@@ -125,6 +399,587 @@ async def test_watch_pods(example_pod_spec, ns):
                 break
 
 
+async def test_watch_many(example_pod_spec, ns):
+    kubernetes = await kr8s.asyncio.api()
+    pod = await Pod(example_pod_spec)
+    await pod.create()
+    config_map = await ConfigMap(
+        {"metadata": {"name": pod.name, "namespace": ns}, "data": {"a": "b"}}
+    )
+    await config_map.create()
+    seen = set()
+    async for event in kubernetes.watch_many(
+        [{"kind": "pods", "namespace": ns}, {"kind": "configmaps", "namespace": ns}],
+        buffer_size=1,
+    ):
+        assert event.namespace == ns
+        if event.object.name == pod.name:
+            seen.add((event.kind, event.version, event.type))
+        if len(seen) == 2:
+            break
+    assert seen == {("Pod", "v1", "ADDED"), ("ConfigMap", "v1", "ADDED")}
+    await pod.delete()
+    await config_map.delete()
+
+
+async def test_watch_many_errors():
+    def handler(request):
+        if request.url.path == "/api/v1/namespaces/forbidden/configmaps":
+            return httpx.Response(
+                404, json={"kind": "Status", "code": 404, "reason": "NotFound"}
+            )
+        pod = {"metadata": {"name": "web", "namespace": "default", "uid": "1"}}
+        line = json.dumps({"type": "ADDED", "object": pod}) + "\n"
+        return httpx.Response(200, content=line.encode())
+
+    kubernetes = await kr8s.asyncio.api(
+        url="http://kr8s.test", transport=httpx.MockTransport(handler)
+    )
+    events = []
+    async for event in kubernetes.watch_many(
+        ["pods", {"kind": "configmaps", "namespace": "forbidden"}]
+    ):
+        events.append(event)
+        # Wait for the pod watch to carry on after the other watch failed
+        types = [e.type for e in events]
+        if "ERROR" in types and "ADDED" in types[types.index("ERROR") :]:
+            break
+    [error] = [e for e in events if e.type == "ERROR"]
+    assert (error.kind, error.version) == ("ConfigMap", "v1")
+    assert error.namespace == "forbidden"
+    assert isinstance(error.object, kr8s.NotFoundError)
+    added = [e for e in events if e.type == "ADDED"]
+    assert {(e.kind, e.version, e.namespace) for e in added} == {
+        ("Pod", "v1", "default")
+    }
+
+
+async def test_watch_many_retries():
+    requests = []
+
+    def handler(request):
+        requests.append(request)
+        if len(requests) < 3:
+            return httpx.Response(
+                503,
+                json={"kind": "Status", "code": 503, "reason": "ServiceUnavailable"},
+            )
+        pod = {"metadata": {"name": "web", "namespace": "default", "uid": "1"}}
+        line = json.dumps({"type": "ADDED", "object": pod}) + "\n"
+        return httpx.Response(200, content=line.encode())
+
+    kubernetes = await kr8s.asyncio.api(
+        url="http://kr8s.test",
+        transport=httpx.MockTransport(handler),
+        retry=kr8s.RetryPolicy(max_attempts=1, backoff_base=0),
+    )
+    async for event in kubernetes.watch_many(["pods"]):
+        # Transient errors are retried rather than stopping the watch
+        assert event.type == "ADDED"
+        assert event.object.name == "web"
+        break
+    assert len(requests) == 3
+
+
+@pytest.mark.parametrize("reconnect", [False, True])
+async def test_watch_reconnect(reconnect):
+    requests = []
+
+    def handler(request):
+        requests.append(request)
+        version = str(len(requests))
+        pod = {"metadata": {"name": "web", "namespace": "default"}}
+        pod["metadata"]["resourceVersion"] = version
+        line = json.dumps({"type": "MODIFIED", "object": pod}) + "\n"
+        # The server closes the watch after each event
+        return httpx.Response(200, content=line.encode())
+
+    kubernetes = await kr8s.asyncio.api(
+        url="http://kr8s.test", transport=httpx.MockTransport(handler)
+    )
+    versions = []
+    async for _, pod in kubernetes.watch("pods", reconnect=reconnect):
+        versions.append(pod.metadata.resourceVersion)
+        if len(versions) == 3:
+            break
+    if reconnect:
+        assert versions == ["1", "2", "3"]
+        assert requests[1].url.params["resourceVersion"] == "1"
+    else:
+        assert versions == ["1"]
+
+
+async def test_watch_pods_resume(example_pod_spec, ns):
+    pod = await Pod(example_pod_spec)
+    await pod.create()
+    since = pod.metadata.resourceVersion
+    await pod.label(resumed="true")
+    async for event, obj in kr8s.asyncio.watch(
+        "pods", namespace=ns, since=since, allow_bookmarks=True
+    ):
+        assert event in ["ADDED", "MODIFIED", "DELETED", "BOOKMARK"]
+        if event == "MODIFIED" and obj.name == pod.name and "resumed" in obj.labels:
+            assert int(obj.metadata.resourceVersion) > int(since)
+            break
+    await pod.delete()
+
+
+async def test_impersonate(ns):
+    kubernetes = await kr8s.asyncio.api()
+    admin = kubernetes.impersonate(
+        "alice", groups=["system:masters", "dev"], extra={"scopes": ["a", "b"]}
+    )
+    assert admin is not kubernetes
+    assert kubernetes._impersonate is None
+    assert admin._impersonation_headers() == [
+        ("Impersonate-User", "alice"),
+        ("Impersonate-Group", "system:masters"),
+        ("Impersonate-Group", "dev"),
+        ("Impersonate-Extra-scopes", "a"),
+        ("Impersonate-Extra-scopes", "b"),
+    ]
+    pods = await admin.get("pods", namespace=ns)
+    assert isinstance(pods, list)
+
+    nobody = kubernetes.impersonate("nobody")
+    with pytest.raises(httpx.HTTPStatusError) as e:
+        await nobody.get("pods", namespace=ns)
+    assert e.value.response.status_code == 403
+    assert "nobody" in e.value.response.json()["message"]
+
+    sa = kubernetes.impersonate(serviceaccount=f"{ns}/default")
+    assert sa._impersonate["user"] == f"system:serviceaccount:{ns}:default"
+    with pytest.raises(ValueError):
+        kubernetes.impersonate("alice", serviceaccount="default")
+
+
+async def test_with_context(k8s_cluster, tmp_path):
+    kubeconfig = yaml.safe_load(k8s_cluster.kubeconfig_path.read_text())
+    [context] = kubeconfig["contexts"]
+    kubeconfig["contexts"] = [
+        {"name": "prod", "context": {**context["context"], "namespace": "prod"}},
+        {"name": "staging", "context": {**context["context"], "namespace": "staging"}},
+    ]
+    kubeconfig["current-context"] = "prod"
+    path = tmp_path / "kubeconfig"
+    path.write_text(yaml.safe_dump(kubeconfig))
+
+    prod = await kr8s.asyncio.api(kubeconfig=str(path))
+    assert prod.namespace == "prod"
+    staging = await prod.with_context("staging")
+    assert staging.namespace == "staging"
+    assert staging is not prod
+    assert staging.auth is not prod.auth
+    assert await prod.with_context("staging") is staging
+    assert await kr8s.asyncio.api(kubeconfig=str(path), context="staging") is staging
+
+    assert "major" in await prod.version()
+    assert "major" in await staging.version()
+    assert staging._session is not prod._session
+
+    with pytest.raises(ValueError, match="Context missing not found"):
+        await prod.with_context("missing")
+
+
+async def test_proxy(k8s_cluster):
+    servers = []
+
+    def no_proxy(server):
+        servers.append(server)
+        return None
+
+    kubernetes = await kr8s.asyncio.api(
+        kubeconfig=k8s_cluster.kubeconfig_path, proxy=no_proxy
+    )
+    assert "major" in await kubernetes.version()
+    assert servers == [kubernetes.auth.server]
+
+    kubernetes = await kr8s.asyncio.api(
+        kubeconfig=k8s_cluster.kubeconfig_path, proxy="http://127.0.0.1:1", retry=False
+    )
+    with pytest.raises(httpx.TransportError):
+        await kubernetes.version()
+
+
+async def test_namespaced():
+    paths = []
+
+    def handler(request):
+        paths.append(request.url.path)
+        kind = "NodeList" if request.url.path.endswith("nodes") else "PodList"
+        return httpx.Response(200, json={"kind": kind, "items": []})
+
+    kubernetes = await kr8s.asyncio.api(
+        url="http://kr8s.test", transport=httpx.MockTransport(handler)
+    )
+    team = kubernetes.namespaced("team-a")
+    assert team.namespace == "team-a"
+    assert kubernetes.namespace != "team-a"
+    await team.get("pods")
+    await team.get("pods", namespace="team-b")
+    await team.get("nodes")
+    assert paths == [
+        "/api/v1/namespaces/team-a/pods",
+        "/api/v1/namespaces/team-b/pods",
+        "/api/v1/nodes",
+    ]
+    assert team._root is kubernetes
+    assert kubernetes._session is not None
+    assert team.namespaced("team-c")._root is kubernetes
+
+    pod = await Pod("web", api=team)
+    assert pod.namespace == "team-a"
+
+
+async def test_websocket_socks_proxy():
+    kubernetes = await kr8s.asyncio.api(
+        url="http://kr8s.test", proxy="socks5://proxy.kr8s.test:1080"
+    )
+    with pytest.raises(NotImplementedError, match="SOCKS"):
+        async with kubernetes.open_websocket(url="pods/web/exec"):
+            pass
+
+
+async def test_transport():
+    requests = []
+
+    def handler(request):
+        requests.append(request)
+        return httpx.Response(
+            200,
+            json={
+                "apiVersion": "v1",
+                "kind": "Pod",
+                "metadata": {"name": "foo", "namespace": "default"},
+            },
+        )
+
+    kubernetes = await kr8s.asyncio.api(
+        url="http://kr8s.test", transport=httpx.MockTransport(handler)
+    )
+    pod = await Pod({"metadata": {"name": "foo"}}, namespace="default", api=kubernetes)
+    await pod.refresh()
+    assert pod.raw["kind"] == "Pod"
+    assert requests[-1].url == "http://kr8s.test/api/v1/namespaces/default/pods/foo"
+    assert requests[-1].headers["User-Agent"].startswith("kr8s/")
+    assert requests[-1].headers["Accept"].startswith("application/json")
+
+
+async def test_content_type():
+    accepts = []
+
+    def handler(request):
+        accepts.append(request.headers["Accept"])
+        if request.url.path == "/healthz":
+            return httpx.Response(200, text="ok")
+        if request.url.path.endswith("/protobuf"):
+            return httpx.Response(
+                200,
+                content=b"k8s\x00",
+                headers={"Content-Type": "application/vnd.kubernetes.protobuf"},
+            )
+        return httpx.Response(200, json={"kind": "Pod", "metadata": {"name": "foo"}})
+
+    kubernetes = await kr8s.asyncio.api(
+        url="http://kr8s.test", transport=httpx.MockTransport(handler)
+    )
+    async with kubernetes.call_api("GET", url="pods/foo", namespace="default") as r:
+        assert r.json()["kind"] == "Pod"
+    assert accepts[-1] == "application/json"
+
+    # Other endpoints can serve anything
+    async with kubernetes.call_api("GET", version="", base="/healthz") as r:
+        assert r.text == "ok"
+    assert accepts[-1] == "application/json, */*"
+
+    with pytest.raises(kr8s.UnsupportedContentTypeError) as e:
+        async with kubernetes.call_api("GET", url="pods/protobuf"):
+            pass
+    assert e.value.content_type == "application/vnd.kubernetes.protobuf"
+
+
+async def test_replaced_session_stays_open():
+    def handler(request):
+        return httpx.Response(200, json={"kind": "Pod", "metadata": {"name": "foo"}})
+
+    kubernetes = await kr8s.asyncio.api(
+        url="http://kr8s.test", transport=httpx.MockTransport(handler)
+    )
+    async with kubernetes.call_api("GET", url="pods/foo", stream=True) as r:
+        old_session = kubernetes._session
+        # Replace the session while the response is still being read
+        await kubernetes._create_session()
+        assert kubernetes._session is not old_session
+        assert not old_session.is_closed
+        await r.aread()
+        assert r.json()["kind"] == "Pod"
+    assert old_session.is_closed
+    assert not kubernetes._session.is_closed
+    assert not kubernetes._session_requests
+
+
+@pytest.mark.parametrize("compression", [None, False])
+async def test_compression(compression):
+    requests = []
+    body = json.dumps({"major": "1", "minor": "28"}).encode()
+
+    def handler(request):
+        requests.append(request)
+        if request.headers["Accept-Encoding"] == "gzip":
+            return httpx.Response(
+                200, content=gzip.compress(body), headers={"Content-Encoding": "gzip"}
+            )
+        return httpx.Response(200, content=body)
+
+    kubernetes = await kr8s.asyncio.api(
+        url="http://kr8s.test",
+        transport=httpx.MockTransport(handler),
+        compression=compression,
+    )
+    assert await kubernetes.version() == {"major": "1", "minor": "28"}
+    expected = "identity" if compression is False else "gzip"
+    assert requests[-1].headers["Accept-Encoding"] == expected
+
+
+async def test_warning_handler(capsys):
+    def handler(request):
+        headers = [
+            ("Warning", '299 - "unknown field \\"spec.foo\\""'),
+            ("Warning", '299 - "v1beta1 is deprecated"'),
+        ]
+        return httpx.Response(200, json={"major": "1"}, headers=headers)
+
+    collector = kr8s.WarningCollector()
+    kubernetes = await kr8s.asyncio.api(
+        url="http://kr8s.test",
+        transport=httpx.MockTransport(handler),
+        warning_handler=collector,
+    )
+    await kubernetes.version()
+    assert collector.texts == ['unknown field "spec.foo"', "v1beta1 is deprecated"]
+    assert collector.warnings[0] == (299, "-", 'unknown field "spec.foo"')
+    await kubernetes.version(refresh=True)
+    assert len(collector.warnings) == 4
+
+    kubernetes = await kr8s.asyncio.api(
+        url="http://kr8s.test", transport=httpx.MockTransport(handler)
+    )
+    await kubernetes.version()
+    await kubernetes.version(refresh=True)
+    assert capsys.readouterr().err.count("Warning: v1beta1 is deprecated") == 1
+
+
+async def test_warning_handler_text_only(caplog):
+    def handler(request):
+        headers = [("Warning", '299 - "v1beta1 is deprecated"'), ("Warning", "oops")]
+        return httpx.Response(200, json={"major": "1"}, headers=headers)
+
+    # Handlers which take one argument are only given the text
+    logger = logging.getLogger("kr8s.test")
+    kubernetes = await kr8s.asyncio.api(
+        url="http://kr8s.test",
+        transport=httpx.MockTransport(handler),
+        warning_handler=logger.warning,
+    )
+    await kubernetes.version()
+    messages = [record.getMessage() for record in caplog.records]
+    assert messages == ["v1beta1 is deprecated", "oops"]
+
+    # Malformed headers are passed on as they are
+    collector = kr8s.WarningCollector()
+    kubernetes = await kr8s.asyncio.api(
+        url="http://kr8s.test",
+        transport=httpx.MockTransport(handler),
+        warning_handler=collector,
+    )
+    await kubernetes.version()
+    assert collector.warnings[1] == (299, "-", "oops")
+
+
+async def test_timeout():
+    requests = []
+
+    def handler(request):
+        requests.append(request)
+        if request.url.path == "/version":
+            raise httpx.ReadTimeout("timed out", request=request)
+        return httpx.Response(200, json={"kind": "PodList", "items": []})
+
+    kubernetes = await kr8s.asyncio.api(
+        url="http://kr8s.test",
+        transport=httpx.MockTransport(handler),
+        retry=False,
+        timeout=2,
+    )
+    with pytest.raises(kr8s.RequestTimeoutError) as e:
+        await kubernetes.version()
+    assert e.value.timeout == 2
+    assert isinstance(e.value, TimeoutError)
+    assert not isinstance(e.value, kr8s.APIError)
+    assert requests[-1].extensions["timeout"]["read"] == 2
+    assert "timeout" not in requests[-1].url.params
+
+    await kubernetes.get("pods", namespace="default")
+    assert requests[-1].url.params["timeout"] == "2s"
+
+    # Per request timeouts override the client's
+    await kubernetes.get("pods", namespace="default", timeout=10)
+    assert requests[-1].url.params["timeout"] == "10s"
+    await kubernetes.get("pods", namespace="default", timeout=False)
+    assert requests[-1].extensions["timeout"]["read"] is None
+    assert "timeout" not in requests[-1].url.params
+
+    # Proxied requests are passed on to the service as they are
+    async with kubernetes.call_api(
+        "GET", url="services/web:80/proxy/", namespace="default"
+    ):
+        pass
+    assert requests[-1].extensions["timeout"]["read"] == 2
+    assert "timeout" not in requests[-1].url.params
+
+    # Streaming requests don't time out between reads
+    async with kubernetes.call_api("GET", url="pods", stream=True):
+        pass
+    assert requests[-1].extensions["timeout"]["read"] is None
+    assert "timeout" not in requests[-1].url.params
+
+
+async def test_top_pods():
+    requests = []
+
+    def handler(request):
+        requests.append(request)
+        return httpx.Response(
+            200,
+            json={
+                "apiVersion": "metrics.k8s.io/v1beta1",
+                "kind": "PodMetricsList",
+                "items": [
+                    {
+                        "metadata": {"name": "foo", "namespace": "default"},
+                        "containers": [
+                            {"name": "a", "usage": {"cpu": "250m", "memory": "64Mi"}},
+                            {"name": "b", "usage": {"cpu": "5e-1", "memory": "1Gi"}},
+                        ],
+                    }
+                ],
+            },
+        )
+
+    kubernetes = await kr8s.asyncio.api(
+        url="http://kr8s.test", transport=httpx.MockTransport(handler)
+    )
+    [pod] = await kubernetes.top_pods(namespace="default", label_selector="app=foo")
+    assert requests[-1].url.path == (
+        "/apis/metrics.k8s.io/v1beta1/namespaces/default/pods"
+    )
+    assert requests[-1].url.params["labelSelector"] == "app=foo"
+    assert pod.kind == "PodMetrics"
+    assert pod.containers["a"]["memory"] == 64 * 1024**2
+    assert pod.usage["cpu"] == Decimal("0.75")
+    assert pod.usage["memory"] > kr8s.parse_quantity("1G")
+
+
+async def test_quota_usage():
+    def quota(name, hard, used):
+        return {
+            "metadata": {"name": name, "namespace": "team"},
+            "status": {"hard": hard, "used": used},
+        }
+
+    def handler(request):
+        assert request.url.path == "/api/v1/namespaces/team/resourcequotas"
+        return httpx.Response(
+            200,
+            json={
+                "kind": "ResourceQuotaList",
+                "items": [
+                    quota(
+                        "compute",
+                        {"requests.cpu": "4", "requests.memory": "8Gi"},
+                        {"requests.cpu": "3500m", "requests.memory": "2Gi"},
+                    ),
+                    quota("pods", {"pods": "10", "requests.cpu": "10"}, {"pods": "1"}),
+                ],
+            },
+        )
+
+    kubernetes = await kr8s.asyncio.api(
+        url="http://kr8s.test", transport=httpx.MockTransport(handler)
+    )
+    usage = await kubernetes.quota_usage(namespace="team")
+    assert usage["requests.cpu"] == (Decimal("3.5"), Decimal(4))
+    assert usage["requests.memory"].utilization == Decimal("0.25")
+    assert usage["pods"].used == 1
+    over = await kubernetes.quota_usage(namespace="team", threshold=0.8)
+    assert list(over) == ["requests.cpu"]
+
+
+async def test_top_nodes_metrics_unavailable():
+    def handler(request):
+        return httpx.Response(
+            404,
+            json={"kind": "Status", "code": 404, "reason": "NotFound"},
+        )
+
+    kubernetes = await kr8s.asyncio.api(
+        url="http://kr8s.test", transport=httpx.MockTransport(handler)
+    )
+    with pytest.raises(kr8s.MetricsUnavailableError, match="metrics-server"):
+        await kubernetes.top_nodes()
+
+
+async def test_transport_wrapper(k8s_cluster):
+    requests = []
+
+    class RecordingTransport(httpx.AsyncBaseTransport):
+        def __init__(self, transport):
+            self.transport = transport
+
+        async def handle_async_request(self, request):
+            requests.append(request)
+            return await self.transport.handle_async_request(request)
+
+    kubernetes = await kr8s.asyncio.api(
+        kubeconfig=k8s_cluster.kubeconfig_path, transport=RecordingTransport
+    )
+    assert "major" in await kubernetes.version()
+    assert requests[-1].url.path == "/version"
+
+
+@pytest.mark.parametrize("proxy", ["http://proxy.kr8s.test:3128", None])
+async def test_transport_wrapper_with_proxy(monkeypatch, proxy):
+    monkeypatch.setenv("HTTP_PROXY", "http://env-proxy.kr8s.test:3128")
+    monkeypatch.delenv("NO_PROXY", raising=False)
+    monkeypatch.delenv("no_proxy", raising=False)
+    proxies = []
+    requests = []
+
+    class DefaultTransport(httpx.AsyncHTTPTransport):
+        def __init__(self, *args, proxy=None, **kwargs):
+            proxies.append(proxy)
+            super().__init__(*args, proxy=proxy, **kwargs)
+
+    class RecordingTransport(httpx.AsyncBaseTransport):
+        def __init__(self, transport):
+            assert isinstance(transport, DefaultTransport)
+
+        async def handle_async_request(self, request):
+            requests.append(request)
+            return httpx.Response(200, json={"major": "1", "minor": "28"})
+
+    monkeypatch.setattr(httpx, "AsyncHTTPTransport", DefaultTransport)
+    kubernetes = await kr8s.asyncio.api(
+        url="http://kr8s.test", proxy=proxy, transport=RecordingTransport
+    )
+    assert kubernetes._env_proxy_url() == "http://env-proxy.kr8s.test:3128"
+    assert "major" in await kubernetes.version()
+    assert requests[-1].url.path == "/version"
+    # The default transport is sent through the configured or environment proxy
+    [default_proxy] = proxies
+    assert default_proxy.url == httpx.URL(proxy or "http://env-proxy.kr8s.test:3128")
+
+
 async def test_get_deployments():
     kubernetes = await kr8s.asyncio.api()
     deployments = await kubernetes.get("deployments")
@@ -154,6 +1009,85 @@ async def test_api_resources():
     assert "deploy" in deployment["shortNames"]
 
 
+async def test_server_resources():
+    kubernetes = await kr8s.asyncio.api()
+    discovery = await kubernetes.server_resources()
+    assert "v1" in discovery
+    assert "apps/v1" in discovery
+    assert discovery["apps/v1"]["kind"] == "APIResourceList"
+    assert await kubernetes.server_resources() is discovery
+    assert await kubernetes.server_resources(refresh=True) is not discovery
+
+    deployment = await kubernetes._lookup_resource("deploy")
+    assert deployment["kind"] == "Deployment"
+    assert deployment["scale_subresource"]
+    deployment = await kubernetes._lookup_resource("deployments.apps")
+    assert deployment["version"] == "apps/v1"
+    with pytest.raises(KeyError):
+        await kubernetes._lookup_resource("notarealresource")
+
+
+async def test_discovery_single_flight(monkeypatch):
+    discoveries = []
+
+    def handler(request):
+        if request.url.path == "/api":
+            discoveries.append(request)
+            return httpx.Response(200, json={"versions": ["v1"]})
+        if request.url.path == "/apis":
+            group = {"groupVersion": "apps/v1", "version": "v1"}
+            return httpx.Response(
+                200,
+                json={
+                    "groups": [
+                        {"name": "apps", "versions": [group], "preferredVersion": group}
+                    ]
+                },
+            )
+        name, kind = {
+            "/api/v1": ("pods", "Pod"),
+            "/apis/apps/v1": ("deployments", "Deployment"),
+        }[request.url.path]
+        return httpx.Response(
+            200,
+            json={
+                "kind": "APIResourceList",
+                "resources": [{"name": name, "kind": kind, "namespaced": True}],
+            },
+        )
+
+    kubernetes = await kr8s.asyncio.api(
+        url="http://kr8s.test", transport=httpx.MockTransport(handler)
+    )
+    await asyncio.gather(
+        *[kubernetes.server_resources(refresh=True) for _ in range(5)]
+    )
+    assert len(discoveries) == 1
+    assert list(await kubernetes.server_resources()) == ["v1", "apps/v1"]
+
+    # Missing resources don't trigger discovery again straight away
+    for _ in range(3):
+        with pytest.raises(KeyError):
+            await kubernetes._lookup_resource("notarealresource")
+    assert len(discoveries) == 1
+
+    monkeypatch.setattr(kr8s._api, "DISCOVERY_REFRESH_INTERVAL", 0)
+    with pytest.raises(KeyError):
+        await kubernetes._lookup_resource("notarealresource")
+    assert len(discoveries) == 2
+    deployment = await kubernetes._lookup_resource("deployments")
+    assert deployment["version"] == "apps/v1"
+
+
+async def test_get_unregistered_kind():
+    kubernetes = await kr8s.asyncio.api()
+    leases = await kubernetes.get("leases", namespace="kube-system")
+    assert leases
+    assert leases[0].kind == "Lease"
+    assert leases[0].version == "coordination.k8s.io/v1"
+    assert leases[0].endpoint == "leases"
+
+
 async def test_ns(ns):
     api = await kr8s.asyncio.api(namespace=ns)
     assert ns == api.namespace
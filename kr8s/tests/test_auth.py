@@ -1,21 +1,34 @@
 # SPDX-FileCopyrightText: Copyright (c) 2023 NVIDIA
 # SPDX-License-Identifier: BSD 3-Clause License
+import base64
+import datetime
+import functools
+import json
+import os
+import stat
 import sys
 import tempfile
 from pathlib import Path
 
+import anyio
 import httpx
 import pytest
 import yaml
 
 import kr8s
+from kr8s._auth import KubeAuth, _jwt_expiry, _parse_timestamp
 from kr8s._testutils import set_env
 
 HERE = Path(__file__).parent.resolve()
 
 
-@pytest.fixture
-async def kubeconfig_with_exec(k8s_cluster):
+@pytest.fixture(
+    params=[
+        "client.authentication.k8s.io/v1",
+        "client.authentication.k8s.io/v1beta1",
+    ]
+)
+async def kubeconfig_with_exec(request, k8s_cluster):
     # Open kubeconfig and extract the certificates
     kubeconfig = yaml.safe_load(k8s_cluster.kubeconfig_path.read_text())
     user = kubeconfig["users"][0]["user"]
@@ -27,9 +40,10 @@ async def kubeconfig_with_exec(k8s_cluster):
     ):
         kubeconfig["users"][0]["user"] = {
             "exec": {
-                "apiVersion": "client.authentication.k8s.io/v1beta1",
+                "apiVersion": request.param,
                 "command": sys.executable,
                 "args": [str(HERE / "scripts" / "envexec.py")],
+                "interactiveMode": "Never",
             }
         }
         with tempfile.NamedTemporaryFile() as f:
@@ -105,3 +119,327 @@ async def test_exec(kubeconfig_with_exec):
     kubernetes = await kr8s.asyncio.api(kubeconfig=kubeconfig_with_exec)
     version = await kubernetes.version()
     assert "major" in version
+
+
+async def test_exec_caches_credential(kubeconfig_with_exec, tmp_path):
+    count_file = tmp_path / "count"
+    expiry = datetime.datetime.now(datetime.timezone.utc) + datetime.timedelta(hours=1)
+    with set_env(
+        KUBE_EXEC_COUNT_FILE=str(count_file),
+        KUBE_EXEC_EXPIRATION=expiry.strftime("%Y-%m-%dT%H:%M:%SZ"),
+    ):
+        kubernetes = await kr8s.asyncio.api(kubeconfig=kubeconfig_with_exec)
+        await kubernetes.version()
+        await kubernetes.version()
+        calls = count_file.read_text().splitlines()
+        assert len(calls) == 1
+        exec_info = json.loads(calls[0])
+        assert exec_info["kind"] == "ExecCredential"
+        assert exec_info["spec"]["interactive"] is False
+        assert not kubernetes.auth.expired
+
+        # Once the credential expires the plugin is run again
+        kubernetes.auth._exec_expiry = datetime.datetime.now(datetime.timezone.utc)
+        assert kubernetes.auth.expired
+        await kubernetes.version()
+        assert len(count_file.read_text().splitlines()) == 2
+
+
+async def test_exec_plugin_not_found(k8s_cluster):
+    kubeconfig = yaml.safe_load(k8s_cluster.kubeconfig_path.read_text())
+    kubeconfig["users"][0]["user"] = {
+        "exec": {
+            "apiVersion": "client.authentication.k8s.io/v1",
+            "command": "kr8s-not-a-real-auth-plugin",
+            "interactiveMode": "Never",
+            "installHint": "Install the plugin from somewhere",
+        }
+    }
+    with tempfile.NamedTemporaryFile() as f:
+        f.write(yaml.safe_dump(kubeconfig).encode())
+        f.flush()
+        with pytest.raises(FileNotFoundError, match="not found") as e:
+            await kr8s.asyncio.api(kubeconfig=f.name)
+        assert "Install the plugin from somewhere" in str(e.value)
+
+
+async def test_exec_unsupported_api_version(k8s_cluster):
+    kubeconfig = yaml.safe_load(k8s_cluster.kubeconfig_path.read_text())
+    kubeconfig["users"][0]["user"] = {
+        "exec": {
+            "apiVersion": "client.authentication.k8s.io/v1alpha1",
+            "command": sys.executable,
+        }
+    }
+    with tempfile.NamedTemporaryFile() as f:
+        f.write(yaml.safe_dump(kubeconfig).encode())
+        f.flush()
+        with pytest.raises(ValueError, match="not supported"):
+            await kr8s.asyncio.api(kubeconfig=f.name)
+
+
+async def test_credential_source_order(k8s_cluster, serviceaccount):
+    with set_env(KUBECONFIG=str(k8s_cluster.kubeconfig_path)):
+        kubernetes = await kr8s.asyncio.api(serviceaccount=serviceaccount)
+        assert kubernetes.auth.token == (Path(serviceaccount) / "token").read_text()
+
+        kr8s._api.Api._instances.clear()
+        kubernetes = await kr8s.asyncio.api()
+        assert kubernetes.auth._kubeconfig == str(k8s_cluster.kubeconfig_path)
+        assert "major" in await kubernetes.version()
+
+
+async def test_service_account_token_reload(k8s_cluster, serviceaccount):
+    kubernetes = await kr8s.asyncio.api(
+        serviceaccount=serviceaccount, kubeconfig="/no/file/here"
+    )
+    await kubernetes.version()
+    assert not kubernetes.auth.token_stale
+
+    # Rotate the token like the kubelet would
+    token = k8s_cluster.kubectl("create", "token", "pytest")
+    (Path(serviceaccount) / "token").write_text(token)
+    kubernetes.auth._token_loaded_at -= kr8s._auth.TOKEN_RELOAD_INTERVAL
+    assert kubernetes.auth.token_stale
+
+    await kubernetes.version()
+    assert kubernetes.auth.token == token
+    assert not kubernetes.auth.token_stale
+
+
+async def test_load_kubeconfig_merge(tmp_path):
+    first = {
+        "clusters": [
+            {
+                "name": "dev",
+                "cluster": {"server": "https://dev", "certificate-authority": "ca.crt"},
+            }
+        ],
+        "contexts": [{"name": "dev", "context": {"cluster": "dev", "user": "alice"}}],
+        "users": [{"name": "alice", "user": {"token": "first"}}],
+    }
+    second = {
+        "current-context": "prod",
+        "clusters": [
+            {"name": "dev", "cluster": {"server": "https://other"}},
+            {"name": "prod", "cluster": {"server": "https://prod"}},
+        ],
+        "contexts": [
+            {"name": "dev", "context": {"cluster": "prod", "user": "bob"}},
+            {"name": "prod", "context": {"cluster": "prod", "user": "bob"}},
+        ],
+        "users": [
+            {"name": "alice", "user": {"token": "second"}},
+            {"name": "bob", "user": {"tokenFile": "~/token"}},
+        ],
+    }
+    (tmp_path / "first").mkdir()
+    (tmp_path / "first" / "config").write_text(yaml.safe_dump(first))
+    (tmp_path / "second").write_text(yaml.safe_dump(second))
+
+    config = await kr8s.asyncio.load_kubeconfig(
+        tmp_path / "first" / "config", tmp_path / "missing", tmp_path / "second"
+    )
+    assert config["current-context"] == "prod"
+    clusters = {c["name"]: c["cluster"] for c in config["clusters"]}
+    assert clusters["dev"]["server"] == "https://dev"
+    assert clusters["dev"]["certificate-authority"] == str(
+        tmp_path / "first" / "ca.crt"
+    )
+    assert clusters["prod"]["server"] == "https://prod"
+    contexts = {c["name"]: c["context"] for c in config["contexts"]}
+    assert contexts["dev"]["user"] == "alice"
+    users = {u["name"]: u["user"] for u in config["users"]}
+    assert users["alice"]["token"] == "first"
+    assert users["bob"]["tokenFile"] == str(Path("~/token").expanduser())
+
+
+async def test_kubeconfig_path_list(k8s_cluster, tmp_path):
+    paths = os.pathsep.join(
+        [str(tmp_path / "missing"), str(k8s_cluster.kubeconfig_path)]
+    )
+    with set_env(KUBECONFIG=paths):
+        kubernetes = await kr8s.asyncio.api()
+        assert "major" in await kubernetes.version()
+
+
+@pytest.fixture
+def kubeconfig_cluster(k8s_cluster, tmp_path):
+    """Write a copy of the kubeconfig with changes to the cluster settings."""
+
+    def write(name, **cluster):
+        kubeconfig = yaml.safe_load(k8s_cluster.kubeconfig_path.read_text())
+        kubeconfig["clusters"][0]["cluster"].update(cluster)
+        path = tmp_path / name
+        path.write_text(yaml.safe_dump(kubeconfig))
+        return str(path)
+
+    return write
+
+
+async def test_insecure_skip_tls_verify(kubeconfig_cluster):
+    path = kubeconfig_cluster("insecure", **{"insecure-skip-tls-verify": True})
+    kubernetes = await kr8s.asyncio.api(kubeconfig=path)
+    assert kubernetes.auth.insecure_skip_tls_verify
+    assert "major" in await kubernetes.version()
+
+
+async def test_tls_server_name(kubeconfig_cluster):
+    path = kubeconfig_cluster("sni", **{"tls-server-name": "kubernetes.default"})
+    kubernetes = await kr8s.asyncio.api(kubeconfig=path)
+    assert "major" in await kubernetes.version()
+
+    path = kubeconfig_cluster("wrong", **{"tls-server-name": "wrong.example.com"})
+    kubernetes = await kr8s.asyncio.api(kubeconfig=path, retry=False)
+    with pytest.raises(httpx.ConnectError):
+        await kubernetes.version()
+
+
+async def test_trust_system_ca(k8s_cluster):
+    kubernetes = await kr8s.asyncio.api(
+        kubeconfig=k8s_cluster.kubeconfig_path, trust_system_ca=True
+    )
+    assert "major" in await kubernetes.version()
+
+
+@pytest.fixture
+def kubeconfig_with_cert_files(k8s_cluster, tmp_path):
+    kubeconfig = yaml.safe_load(k8s_cluster.kubeconfig_path.read_text())
+    user = kubeconfig["users"][0]["user"]
+    certs = tmp_path / "certs"
+    certs.mkdir()
+    (certs / "client.crt").write_bytes(
+        base64.b64decode(user.pop("client-certificate-data"))
+    )
+    (certs / "client.key").write_bytes(base64.b64decode(user.pop("client-key-data")))
+    # Paths are relative to the kubeconfig
+    user["client-certificate"] = "certs/client.crt"
+    user["client-key"] = "certs/client.key"
+    path = tmp_path / "kubeconfig"
+    path.write_text(yaml.safe_dump(kubeconfig))
+    return path
+
+
+async def test_client_cert_files(kubeconfig_with_cert_files, monkeypatch):
+    kubernetes = await kr8s.asyncio.api(kubeconfig=str(kubeconfig_with_cert_files))
+    assert kubernetes.auth.client_cert_file == str(
+        kubeconfig_with_cert_files.parent / "certs" / "client.crt"
+    )
+    assert "major" in await kubernetes.version()
+    assert not kubernetes.auth.client_cert_stale
+
+    # Rotating the certificate creates a new session with it
+    monkeypatch.setattr(kr8s._auth, "CLIENT_CERT_CHECK_INTERVAL", 0)
+    cert_file = kubeconfig_with_cert_files.parent / "certs" / "client.crt"
+    stat = cert_file.stat()
+    os.utime(cert_file, ns=(stat.st_atime_ns, stat.st_mtime_ns + 10**9))
+    assert kubernetes.auth.client_cert_stale
+    session = kubernetes._session
+    assert "major" in await kubernetes.version(refresh=True)
+    assert kubernetes._session is not session
+    assert not kubernetes.auth.client_cert_stale
+
+
+async def test_client_cert_file_and_data(k8s_cluster, tmp_path):
+    kubeconfig = yaml.safe_load(k8s_cluster.kubeconfig_path.read_text())
+    kubeconfig["users"][0]["user"]["client-certificate"] = "client.crt"
+    path = tmp_path / "kubeconfig"
+    path.write_text(yaml.safe_dump(kubeconfig))
+    with pytest.raises(ValueError, match="client-certificate"):
+        await kr8s.asyncio.api(kubeconfig=str(path))
+
+
+def _make_jwt(expiry: datetime.datetime) -> str:
+    claims = json.dumps({"exp": int(expiry.timestamp())}).encode()
+    return f"e30.{base64.urlsafe_b64encode(claims).decode().rstrip('=')}.sig"
+
+
+def test_jwt_expiry():
+    expiry = datetime.datetime(2030, 1, 1, tzinfo=datetime.timezone.utc)
+    assert _jwt_expiry(_make_jwt(expiry)) == expiry
+    assert _jwt_expiry("not-a-jwt") < datetime.datetime.now(datetime.timezone.utc)
+
+
+@pytest.mark.parametrize(
+    "timestamp, microsecond",
+    [
+        ("2030-01-01T00:00:00Z", 0),
+        ("2030-01-01T00:00:00.5Z", 500000),
+        ("2030-01-01T00:00:00.123456Z", 123456),
+        ("2030-01-01T00:00:00.123456789Z", 123456),
+        ("2030-01-01T00:00:00.123456789+00:00", 123456),
+    ],
+)
+def test_parse_timestamp(timestamp, microsecond):
+    expected = datetime.datetime(
+        2030, 1, 1, microsecond=microsecond, tzinfo=datetime.timezone.utc
+    )
+    assert _parse_timestamp(timestamp) == expected
+
+
+async def test_oidc_refresh(tmp_path, monkeypatch):
+    now = datetime.datetime.now(datetime.timezone.utc)
+    expired_token = _make_jwt(now - datetime.timedelta(hours=1))
+    new_token = _make_jwt(now + datetime.timedelta(hours=1))
+    token_requests = []
+
+    def handler(request):
+        if request.url.path == "/.well-known/openid-configuration":
+            token_endpoint = "https://idp.test/token"
+            return httpx.Response(200, json={"token_endpoint": token_endpoint})
+        token_requests.append(request)
+        return httpx.Response(
+            200, json={"id_token": new_token, "refresh_token": "new-refresh"}
+        )
+
+    monkeypatch.setattr(
+        httpx,
+        "AsyncClient",
+        functools.partial(httpx.AsyncClient, transport=httpx.MockTransport(handler)),
+    )
+    path = tmp_path / "kubeconfig"
+    path.write_text(
+        yaml.safe_dump(
+            {
+                "current-context": "oidc",
+                "clusters": [{"name": "c", "cluster": {"server": "https://k8s.test"}}],
+                "contexts": [
+                    {"name": "oidc", "context": {"cluster": "c", "user": "u"}}
+                ],
+                "users": [
+                    {
+                        "name": "u",
+                        "user": {
+                            "auth-provider": {
+                                "name": "oidc",
+                                "config": {
+                                    "idp-issuer-url": "https://idp.test",
+                                    "client-id": "kr8s",
+                                    "client-secret": "secret",
+                                    "id-token": expired_token,
+                                    "refresh-token": "old-refresh",
+                                },
+                            }
+                        },
+                    }
+                ],
+            }
+        )
+    )
+
+    path.chmod(0o640)
+
+    auth = KubeAuth(kubeconfig=str(path))
+    async with anyio.create_task_group() as tg:
+        tg.start_soon(auth.reauthenticate)
+        tg.start_soon(auth.reauthenticate)
+    assert auth.token == new_token
+    assert not auth.expired
+    assert len(token_requests) == 1
+    assert b"refresh_token=old-refresh" in token_requests[0].content
+
+    config = yaml.safe_load(path.read_text())["users"][0]["user"]["auth-provider"]
+    assert config["config"]["id-token"] == new_token
+    assert config["config"]["refresh-token"] == "new-refresh"
+    assert stat.S_IMODE(path.stat().st_mode) == 0o640
+    assert [p.name for p in tmp_path.iterdir()] == ["kubeconfig"]
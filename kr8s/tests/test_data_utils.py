@@ -1,7 +1,27 @@
 # SPDX-FileCopyrightText: Copyright (c) 2023, Dask Developers, NVIDIA
 # SPDX-License-Identifier: BSD 3-Clause License
+from decimal import Decimal
 
-from kr8s._data_utils import dict_to_selector, dot_to_nested_dict, list_dict_unpack
+import pytest
+
+from kr8s._data_utils import (
+    QuotaUsage,
+    dict_to_selector,
+    dot_to_nested_dict,
+    list_dict_unpack,
+    parse_quantity,
+)
+
+
+def test_quota_usage():
+    usage = QuotaUsage(used=Decimal("1.5"), hard=Decimal(2))
+    assert usage.utilization == Decimal("0.75")
+    assert usage.over(0.75)
+    assert not usage.over(0.8)
+    empty = QuotaUsage(used=Decimal(0), hard=Decimal(0))
+    assert empty.utilization is None
+    assert not empty.over(0.5)
+    assert QuotaUsage(used=Decimal(1), hard=Decimal(0)).over(1)
 
 
 def test_list_dict_unpack():
@@ -22,3 +42,29 @@ def test_dot_to_nested_dict():
 def test_dict_to_selector():
     assert dict_to_selector({"foo": "bar"}) == "foo=bar"
     assert dict_to_selector({"foo": "bar", "baz": "qux"}) == "foo=bar,baz=qux"
+
+
+@pytest.mark.parametrize(
+    "quantity,expected",
+    [
+        ("1", "1"),
+        ("500m", "0.5"),
+        ("123456789n", "0.123456789"),
+        ("1.5", "1.5"),
+        ("2k", "2000"),
+        ("128Mi", "134217728"),
+        ("1Gi", "1073741824"),
+        ("1e3", "1000"),
+        ("1E", "1000000000000000000"),
+        (2, "2"),
+        (0.25, "0.25"),
+    ],
+)
+def test_parse_quantity(quantity, expected):
+    assert parse_quantity(quantity) == Decimal(expected)
+
+
+@pytest.mark.parametrize("quantity", ["", "abc", "1e", "Mi", "1 Mi", "1.2.3"])
+def test_parse_quantity_invalid(quantity):
+    with pytest.raises(ValueError):
+        parse_quantity(quantity)
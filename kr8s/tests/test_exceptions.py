@@ -0,0 +1,158 @@
+# SPDX-FileCopyrightText: Copyright (c) 2023, Dask Developers, NVIDIA
+# SPDX-License-Identifier: BSD 3-Clause License
+import json
+
+import httpx
+import pytest
+
+import kr8s
+from kr8s._exceptions import api_error_from_response
+from kr8s.asyncio.objects import Pod
+
+
+def make_response(status_code, status=None, request_body=None, params=None):
+    request = httpx.Request(
+        "PUT",
+        "https://k8s/api/v1/namespaces/default/pods/foo",
+        params=params,
+        content=json.dumps(request_body).encode() if request_body else b"",
+    )
+    if status is None:
+        return httpx.Response(status_code, text="oops", request=request)
+    return httpx.Response(
+        status_code, json={"kind": "Status", **status}, request=request
+    )
+
+
+@pytest.mark.parametrize(
+    "code,reason,cls",
+    [
+        (400, "BadRequest", kr8s.BadRequestError),
+        (401, "Unauthorized", kr8s.UnauthorizedError),
+        (403, "Forbidden", kr8s.ForbiddenError),
+        (404, "NotFound", kr8s.NotFoundError),
+        (409, "Conflict", kr8s.ConflictError),
+        (409, "AlreadyExists", kr8s.AlreadyExistsError),
+        (410, "Expired", kr8s.ResourceVersionTooOldError),
+        (422, "Invalid", kr8s.InvalidError),
+        (429, "TooManyRequests", kr8s.TooManyRequestsError),
+        (500, "InternalError", kr8s.APIError),
+    ],
+)
+def test_api_error_types(code, reason, cls):
+    status = {"code": code, "reason": reason, "message": "it broke"}
+    error = api_error_from_response(make_response(code, status))
+    assert type(error) is cls
+    assert isinstance(error, httpx.HTTPStatusError)
+    assert error.code == code
+    assert error.reason == reason
+    assert str(error) == "it broke"
+
+
+def test_api_error_without_status():
+    error = api_error_from_response(make_response(503))
+    assert type(error) is kr8s.APIError
+    assert error.code == 503
+    assert error.reason is None
+    assert error.details == {}
+    assert "503" in str(error)
+
+
+def test_api_error_details():
+    status = {
+        "code": 422,
+        "reason": "Invalid",
+        "message": "Pod is invalid",
+        "details": {"name": "foo", "kind": "pods", "causes": [{"field": "spec"}]},
+    }
+    error = api_error_from_response(make_response(422, status))
+    assert error.details["name"] == "foo"
+    assert error.details["causes"] == [{"field": "spec"}]
+
+
+STRICT_DECODING = {
+    "reason": "BadRequest",
+    "message": 'strict decoding error: unknown field "spec.notAField"',
+}
+
+
+@pytest.mark.parametrize(
+    "status,params,cls",
+    [
+        ({"reason": "Invalid"}, None, kr8s.InvalidError),
+        (
+            {
+                "reason": "BadRequest",
+                "details": {"causes": [{"reason": "FieldValueNotSupported"}]},
+            },
+            None,
+            kr8s.InvalidError,
+        ),
+        (STRICT_DECODING, {"fieldValidation": "Strict"}, kr8s.InvalidError),
+        # The message is only trusted when strict validation was asked for
+        (STRICT_DECODING, None, kr8s.BadRequestError),
+    ],
+)
+def test_bad_request_invalid_fields(status, params, cls):
+    response = make_response(400, {"code": 400, **status}, params=params)
+    assert type(api_error_from_response(response)) is cls
+
+
+def test_conflict_resource_version():
+    status = {"code": 409, "reason": "Conflict", "message": "modified"}
+    body = {"metadata": {"name": "foo", "resourceVersion": "123"}}
+    error = api_error_from_response(make_response(409, status, body))
+    assert error.resource_version == "123"
+    assert kr8s.ConflictError("no request").resource_version is None
+
+
+def test_disruption_budget_retry_after():
+    status = {"code": 429, "reason": "TooManyRequests", "message": "budget"}
+    response = make_response(429, status)
+    error = kr8s.DisruptionBudgetError("budget", response=response, status=status)
+    assert isinstance(error, kr8s.TooManyRequestsError)
+    assert error.retry_after is None
+
+    response.headers["Retry-After"] = "5"
+    assert error.retry_after == 5
+    status["details"] = {"retryAfterSeconds": 10}
+    assert kr8s.DisruptionBudgetError("budget", status=status).retry_after == 10
+
+
+def test_is_helpers():
+    status = {"code": 404, "reason": "NotFound", "message": "missing"}
+    error = api_error_from_response(make_response(404, status))
+    assert kr8s.is_not_found(error)
+    assert not kr8s.is_conflict(error)
+
+    try:
+        try:
+            raise error
+        except kr8s.NotFoundError as e:
+            raise RuntimeError("wrapped") from e
+    except RuntimeError as wrapped:
+        assert kr8s.is_not_found(wrapped)
+        assert not kr8s.is_forbidden(wrapped)
+
+    assert not kr8s.is_invalid(ValueError())
+
+
+async def test_api_errors(example_pod_spec):
+    pod = await Pod(example_pod_spec)
+    with pytest.raises(kr8s.NotFoundError) as e:
+        await pod.refresh()
+    assert e.value.reason == "NotFound"
+    assert e.value.details["name"] == pod.name
+    assert isinstance(e.value.__cause__, httpx.HTTPStatusError)
+
+    await pod.create()
+    duplicate = await Pod(example_pod_spec)
+    with pytest.raises(kr8s.AlreadyExistsError) as e:
+        await duplicate.create()
+    assert kr8s.is_already_exists(e.value)
+
+    with pytest.raises(kr8s.InvalidError) as e:
+        await pod.patch({"spec": {"containers": [{"name": "pause", "image": ""}]}})
+    assert e.value.code == 422
+    assert e.value.details["causes"]
+    await pod.delete()
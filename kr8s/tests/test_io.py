@@ -5,7 +5,7 @@ import pytest
 import trio
 
 import kr8s
-from kr8s._io import NamedTemporaryFile
+from kr8s._io import NamedTemporaryFile, iter_over_async
 from kr8s.asyncio.objects import Pod
 
 
@@ -57,3 +57,20 @@ async def test_tempfiles():
         assert await f.exists()
         assert isinstance(f, anyio.Path)
     assert not await f.exists()
+
+
+def test_iter_over_async_closes_generator():
+    closed = False
+
+    async def numbers():
+        nonlocal closed
+        try:
+            for i in range(10):
+                yield i
+        finally:
+            closed = True
+
+    gen = iter_over_async(numbers)
+    assert next(gen) == 0
+    gen.close()
+    assert closed
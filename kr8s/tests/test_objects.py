@@ -1,33 +1,46 @@
 # SPDX-FileCopyrightText: Copyright (c) 2023, Dask Developers, Yuvi Panda, Anaconda Inc, NVIDIA
 # SPDX-License-Identifier: BSD 3-Clause License
 import asyncio
+import datetime
+import io
+import json
 import pathlib
+import tarfile
 import time
 
+import anyio
 import httpx
 import pytest
 
 import kr8s
 from kr8s.asyncio.objects import (
     APIObject,
+    ConfigMap,
     Deployment,
     Ingress,
+    Job,
+    Node,
     PersistentVolume,
     Pod,
+    PodDisruptionBudget,
+    ReplicationController,
     Service,
     object_from_name_type,
     objects_from_files,
+    objects_from_json,
+    objects_from_yaml,
 )
+from kr8s._objects import _extract_tar
 from kr8s.asyncio.portforward import PortForward
 from kr8s.objects import Pod as SyncPod
-from kr8s.objects import get_class, object_from_spec
+from kr8s.objects import get_class, new_class, object_from_spec
 
 DEFAULT_TIMEOUT = httpx.Timeout(30)
 CURRENT_DIR = pathlib.Path(__file__).parent
 
 
 @pytest.fixture
-async def nginx_pod(k8s_cluster, example_pod_spec, ns):
+async def nginx_pod(k8s_cluster, example_pod_spec):
     example_pod_spec["metadata"]["name"] = (
         "nginx-" + example_pod_spec["metadata"]["name"]
     )
@@ -45,18 +58,14 @@ async def nginx_pod(k8s_cluster, example_pod_spec, ns):
     await pod.create()
     while not await pod.ready():
         await asyncio.sleep(0.1)
-    # TODO replace with pod.exec() once implemented
-    k8s_cluster.kubectl(
-        "exec",
-        example_pod_spec["metadata"]["name"],
-        "-n",
-        ns,
-        "--",
-        "dd",
-        "if=/dev/random",
-        "of=/usr/share/nginx/html/foo.dat",
-        "bs=4M",
-        "count=10",
+    await pod.exec(
+        [
+            "dd",
+            "if=/dev/random",
+            "of=/usr/share/nginx/html/foo.dat",
+            "bs=4M",
+            "count=10",
+        ]
     )
     yield pod
     await pod.delete()
@@ -88,6 +97,67 @@ async def test_pod_create_and_delete(example_pod_spec):
     assert not await pod.exists()
 
 
+async def test_delete_options(example_pod_spec):
+    pod = await Pod(example_pod_spec)
+    await pod.create()
+    with pytest.raises(kr8s.ConflictError):
+        await pod.delete(preconditions={"uid": "not-the-uid"})
+    assert await pod.exists()
+    with pytest.raises(ValueError):
+        await pod.delete(propagation_policy="Sideways")
+    await pod.delete(
+        propagation_policy="Foreground",
+        grace_period=0,
+        preconditions={"uid": pod.metadata.uid},
+    )
+    await pod.wait_deleted(timeout=60)
+    assert not await pod.exists()
+
+
+async def test_pod_evict(example_pod_spec, ns):
+    pod = await Pod(example_pod_spec)
+    await pod.create()
+    await pod.wait_ready(timeout=60)
+    pdb = await PodDisruptionBudget(
+        {
+            "apiVersion": "policy/v1",
+            "kind": "PodDisruptionBudget",
+            "metadata": {"name": pod.name, "namespace": ns},
+            "spec": {"minAvailable": 1, "selector": {"matchLabels": pod.labels}},
+        }
+    )
+    await pdb.create()
+    while not pdb.raw.get("status", {}).get("currentHealthy"):
+        await asyncio.sleep(0.1)
+        await pdb.refresh()
+    with pytest.raises(kr8s.DisruptionBudgetError):
+        await pod.evict()
+    assert await pod.exists()
+    await pdb.delete()
+    await pod.evict(grace_period=0)
+    await pod.wait_deleted(timeout=60)
+
+
+async def test_pod_events(example_pod_spec):
+    pod = await Pod(example_pod_spec)
+    await pod.create()
+    await pod.wait_ready(timeout=60)
+    events = await pod.events()
+    assert events
+    assert "Scheduled" in [event.reason for event in events]
+    assert all(e.last_timestamp <= f.last_timestamp for e, f in zip(events, events[1:]))
+    [scheduled, *_] = [event for event in events if event.reason == "Scheduled"]
+    assert scheduled.type == "Normal"
+    assert scheduled.count >= 1
+    assert scheduled.source["component"]
+    assert pod.name in scheduled.message
+
+    async for event in pod.watch_events():
+        assert event.raw["involvedObject"]["uid"] == pod.metadata.uid
+        break
+    await pod.delete()
+
+
 async def test_pod_object_from_name_type(example_pod_spec):
     pod = await Pod(example_pod_spec)
     await pod.create()
@@ -117,6 +187,25 @@ async def test_pod_wait_ready(example_pod_spec):
     await pod.wait("delete")
 
 
+async def test_pod_wait_helpers(example_pod_spec):
+    pod = await Pod(example_pod_spec)
+    await pod.create()
+    await pod.wait_ready(timeout=60)
+    await pod.wait("jsonpath={.status.phase}=Running")
+    await pod.wait(lambda p: p.status.phase == "Running")
+
+    async def is_ready(p):
+        return await p.ready()
+
+    await pod.wait([is_ready, "condition=Ready"])
+    with pytest.raises(TimeoutError, match="last observed state: phase=Running"):
+        await pod.wait(lambda p: False, timeout=0.5)
+    await pod.delete()
+    await pod.wait_deleted(timeout=60)
+    with pytest.raises(TimeoutError, match="not found"):
+        await pod.wait("condition=NotARealCondition", timeout=0.5)
+
+
 def test_pod_wait_ready_sync(example_pod_spec):
     pod = SyncPod(example_pod_spec)
     pod.create()
@@ -133,6 +222,15 @@ def test_pod_wait_ready_sync(example_pod_spec):
     pod.wait("delete")
 
 
+def test_pod_wait_ready_sync_helper(example_pod_spec):
+    pod = SyncPod(example_pod_spec)
+    pod.create()
+    pod.wait_ready(timeout=60)
+    assert pod.ready()
+    pod.delete()
+    pod.wait_deleted(timeout=60)
+
+
 def test_pod_refresh_sync(example_pod_spec):
     pod = SyncPod(example_pod_spec)
     pod.create()
@@ -345,6 +443,242 @@ async def test_patch_pod(example_pod_spec):
     await pod.delete()
 
 
+async def test_patch_types(example_pod_spec):
+    pod = await Pod(example_pod_spec)
+    await pod.create()
+    await pod.patch({"metadata": {"labels": {"strategic": "true"}}}, type="strategic")
+    assert "strategic" in pod.labels
+    await pod.patch(
+        [{"op": "remove", "path": "/metadata/labels/strategic"}], type="json"
+    )
+    assert "strategic" not in pod.labels
+    with pytest.raises(ValueError, match="list of operations"):
+        await pod.patch({"metadata": {"labels": {"foo": "bar"}}}, type="json")
+    with pytest.raises(ValueError, match="Invalid JSON patch operation"):
+        await pod.patch([{"op": "delete", "path": "/metadata"}], type="json")
+    with pytest.raises(ValueError, match="Unknown patch type"):
+        await pod.patch({}, type="yaml")
+    await pod.delete()
+
+
+async def test_pod_status_subresource(example_pod_spec):
+    pod = await Pod(example_pod_spec)
+    await pod.create()
+    condition = {"type": "kr8s.org/Tested", "status": "True"}
+    await pod.patch_status({"status": {"conditions": [condition]}}, type="strategic")
+    assert pod.status.conditions[-1]["type"] == "kr8s.org/Tested"
+    pod.raw["status"]["conditions"] = []
+    pod.raw["metadata"].setdefault("labels", {})["updated"] = "true"
+    await pod.update()
+    assert pod.labels["updated"] == "true"
+    assert any(c["type"] == "kr8s.org/Tested" for c in pod.status.conditions)
+    await pod.delete()
+
+
+async def test_status_subresource_not_supported():
+    cm = await ConfigMap({"metadata": {"name": "no-status"}, "data": {}})
+    with pytest.raises(NotImplementedError, match="status subresource"):
+        await cm.patch_status({"status": {}})
+
+
+async def test_dry_run(example_pod_spec):
+    pod = await Pod(example_pod_spec)
+    preview = await pod.create(dry_run=True)
+    assert preview is not pod
+    assert preview.metadata.uid
+    # Defaulted fields are included in the would-be result
+    assert preview.spec.restartPolicy == "Always"
+    assert not await pod.exists()
+
+    await pod.create()
+    preview = await pod.patch({"metadata": {"labels": {"dry": "run"}}}, dry_run=True)
+    assert "dry" in preview.labels
+    await pod.refresh()
+    assert "dry" not in pod.labels
+
+    preview = await pod.patch(
+        {"status": {"conditions": [{"type": "Example", "status": "True"}]}},
+        subresource="status",
+        dry_run=True,
+    )
+    assert "Example" in [c["type"] for c in preview.status.conditions]
+
+    pod.raw["metadata"]["labels"]["updated"] = "true"
+    preview = await pod.update(dry_run=True)
+    assert "updated" in preview.labels
+    assert await pod.update() is pod
+    await pod.refresh()
+    assert "updated" in pod.labels
+
+    pod.raw["metadata"]["resourceVersion"] = "1"
+    with pytest.raises(kr8s.ConflictError):
+        await pod.update()
+    await pod.delete()
+
+
+async def test_field_validation(example_pod_spec):
+    example_pod_spec["spec"]["notAField"] = True
+    pod = await Pod(example_pod_spec)
+    with pytest.raises(kr8s.InvalidError, match="notAField"):
+        await pod.create(field_validation="Strict")
+    with pytest.raises(ValueError):
+        await pod.create(field_validation="Loose")
+
+    warnings = []
+    api = await kr8s.asyncio.api(warning_handler=warnings.append)
+    pod = await Pod(example_pod_spec, api=api)
+    await pod.create(dry_run=True, field_validation="Warn")
+    assert any("notAField" in warning for warning in warnings)
+
+
+def test_set_owner():
+    deployment = Deployment(
+        {"metadata": {"name": "web", "namespace": "default", "uid": "1234"}}
+    )
+    other = Deployment({"metadata": {"name": "other", "namespace": "default"}})
+    config = ConfigMap({"metadata": {"name": "web-config", "namespace": "default"}})
+    assert config.owners == []
+    assert config.controller_owner is None
+    assert not config.is_owned_by(deployment)
+
+    with pytest.raises(ValueError, match="no uid"):
+        config.set_owner(other)
+    config.set_owner(deployment, controller=True, block_owner_deletion=True)
+    config.set_owner(deployment, controller=True, block_owner_deletion=True)
+    assert config.owners == [
+        {
+            "apiVersion": "apps/v1",
+            "kind": "Deployment",
+            "name": "web",
+            "uid": "1234",
+            "controller": True,
+            "blockOwnerDeletion": True,
+        }
+    ]
+    assert config.controller_owner.name == "web"
+    assert config.is_owned_by(deployment)
+
+    other.raw["metadata"]["uid"] = "5678"
+    with pytest.raises(ValueError, match="already controlled"):
+        config.set_owner(other, controller=True)
+    config.set_owner(other)
+    assert len(config.owners) == 2
+
+    elsewhere = ConfigMap({"metadata": {"name": "a", "namespace": "b", "uid": "9"}})
+    with pytest.raises(ValueError, match="same namespace"):
+        config.set_owner(elsewhere)
+    # The namespace is defaulted when the child is created
+    unplaced = ConfigMap({"metadata": {"name": "unplaced"}})
+    unplaced.set_owner(deployment)
+    assert unplaced.is_owned_by(deployment)
+    volume = PersistentVolume({"metadata": {"name": "pv"}})
+    with pytest.raises(ValueError, match="Cluster scoped"):
+        volume.set_owner(deployment)
+
+    Widget = new_class("Widget", "example.kr8s.org/v1alpha1")
+    widget = Widget({"metadata": {"name": "w", "namespace": "default", "uid": "42"}})
+    config.set_owner(widget)
+    assert config.owners[-1]["apiVersion"] == "example.kr8s.org/v1alpha1"
+    assert config.owners[-1]["kind"] == "Widget"
+
+
+def test_ready_by_kind(example_pod_spec):
+    example_pod_spec["status"] = {
+        "conditions": [
+            {"type": "Ready", "status": "True"},
+            {"type": "ContainersReady", "status": "True"},
+        ],
+        "containerStatuses": [{"name": "pause", "ready": True}],
+    }
+    pod = Pod(example_pod_spec)
+    assert [c.type for c in pod.conditions] == ["Ready", "ContainersReady"]
+    assert pod._is_ready()
+    pod.raw["status"]["containerStatuses"].append({"name": "sidecar", "ready": False})
+    assert not pod._is_ready()
+
+    deployment = Deployment(
+        {
+            "metadata": {"name": "web", "generation": 2},
+            "spec": {"replicas": 3},
+            "status": {"observedGeneration": 1, "readyReplicas": 3},
+        }
+    )
+    assert not deployment._is_ready()
+    deployment.raw["status"]["observedGeneration"] = 2
+    assert not deployment._is_ready()
+    deployment.raw["status"]["availableReplicas"] = 3
+    assert deployment._is_ready()
+
+    job = Job({"metadata": {"name": "batch"}, "spec": {}, "status": {}})
+    assert not job._is_ready()
+    job.raw["status"]["conditions"] = [{"type": "Failed", "status": "True"}]
+    assert not job._is_ready()
+    job.raw["status"]["conditions"] = [{"type": "Complete", "status": "True"}]
+    assert job._is_ready()
+
+    Widget = new_class("Widget", "example.kr8s.org/v1alpha1")
+    widget = Widget({"metadata": {"name": "foo"}, "spec": {}})
+    assert widget.conditions == []
+    assert not widget._is_ready()
+    widget.raw["status"] = {"conditions": [{"type": "Available", "status": "True"}]}
+    assert widget._is_ready()
+    widget.raw["status"]["conditions"].append({"type": "Ready", "status": "False"})
+    assert not widget._is_ready()
+
+
+async def test_update_with_retry(example_pod_spec):
+    pod = await Pod(example_pod_spec)
+    await pod.create()
+    other = await Pod.get(pod.name, namespace=pod.namespace)
+    calls = []
+
+    async def mutate(obj):
+        calls.append(obj.metadata.resourceVersion)
+        if len(calls) == 1:
+            # Race with another writer so the first update conflicts
+            await other.label(race="lost")
+        obj.raw["metadata"]["labels"]["attempts"] = str(len(calls))
+
+    assert await pod.update_with_retry(mutate) is pod
+    assert len(calls) == 2
+    assert calls[0] != calls[1]
+    assert pod.labels["attempts"] == "2"
+    assert pod.labels["race"] == "lost"
+
+    async def always_race(obj):
+        calls.append(obj.metadata.resourceVersion)
+        await other.label(race=str(len(calls)))
+
+    calls.clear()
+    retry = kr8s.RetryPolicy(max_attempts=2, backoff_base=0)
+    with pytest.raises(kr8s.ConflictError):
+        await pod.update_with_retry(always_race, retry=retry)
+    assert len(calls) == 2
+    await pod.delete()
+
+
+async def test_server_side_apply(example_deployment_spec):
+    deployment = await Deployment(example_deployment_spec)
+    await deployment.apply(field_manager="kr8s-tests")
+    assert await deployment.exists()
+    managers = [f["manager"] for f in deployment.metadata.managedFields]
+    assert "kr8s-tests" in managers
+
+    preview = await deployment.apply(field_manager="kr8s-tests", dry_run=True)
+    assert preview is not deployment
+    assert preview.name == deployment.name
+
+    kubernetes = await kr8s.asyncio.api()
+    example_deployment_spec["spec"]["replicas"] = 2
+    with pytest.raises(kr8s.ConflictError):
+        await kubernetes.apply(example_deployment_spec, field_manager="other")
+    deployment = await kubernetes.apply(
+        example_deployment_spec, field_manager="other", force=True
+    )
+    assert deployment.replicas == 2
+    await deployment.delete()
+
+
 async def test_all_v1_objects_represented():
     kubernetes = await kr8s.asyncio.api()
     k8s_objects = await kubernetes.api_resources()
@@ -365,6 +699,14 @@ async def test_all_v1_objects_represented():
         assert get_class(obj["kind"], obj["version"])
 
 
+async def test_get_class_core_plurals():
+    # The metrics objects share their endpoints with the core objects
+    assert get_class("pods") is Pod
+    assert get_class("nodes") is Node
+    assert get_class("podmetrics").version == "metrics.k8s.io/v1beta1"
+    assert get_class("nodemetrics").version == "metrics.k8s.io/v1beta1"
+
+
 async def test_object_from_spec(example_pod_spec, example_service_spec):
     pod = object_from_spec(example_pod_spec)
     assert isinstance(pod, Pod)
@@ -396,8 +738,11 @@ async def test_deployment_scale(example_deployment_spec):
     deployment = await Deployment(example_deployment_spec)
     await deployment.create()
     assert deployment.replicas == 1
-    await deployment.scale(2)
+    scale = await deployment.scale(2)
+    assert scale["kind"] == "Scale"
+    assert scale["spec"]["replicas"] == 2
     assert deployment.replicas == 2
+    assert await deployment.current_scale() == 2
     while not await deployment.ready():
         await asyncio.sleep(0.1)
     pods = await deployment.pods()
@@ -407,6 +752,59 @@ async def test_deployment_scale(example_deployment_spec):
     await deployment.delete()
 
 
+async def test_deployment_rollout_restart(example_deployment_spec):
+    deployment = await Deployment(example_deployment_spec)
+    await deployment.create()
+    async for progress in deployment.rollout_status(timeout=60):
+        pass
+    assert progress["done"]
+    generation = deployment.metadata.generation
+    await deployment.rollout_restart()
+    annotations = deployment.spec.template.metadata.annotations
+    assert "kubectl.kubernetes.io/restartedAt" in annotations
+    assert deployment.metadata.generation > generation
+    async for progress in deployment.rollout_status(timeout=60):
+        assert progress["desired"] == 1
+    assert progress["done"]
+    await deployment.delete()
+
+
+async def test_rollout_restart_not_restartable(example_pod_spec):
+    pod = await Pod(example_pod_spec)
+    with pytest.raises(NotImplementedError):
+        await pod.rollout_restart()
+
+
+async def test_deployment_rollout_progress():
+    deployment = Deployment(
+        {
+            "metadata": {"name": "web", "namespace": "default", "generation": 2},
+            "spec": {"replicas": 2},
+            "status": {
+                "observedGeneration": 2,
+                "replicas": 2,
+                "updatedReplicas": 2,
+                "readyReplicas": 2,
+                "availableReplicas": 1,
+            },
+        }
+    )
+    progress = deployment._rollout_progress()
+    assert progress["message"] == "1 of 2 updated replicas are available"
+    assert not progress["done"]
+    deployment.raw["status"]["availableReplicas"] = 2
+    assert deployment._rollout_progress()["done"]
+    deployment.raw["status"]["conditions"] = [
+        {"type": "Progressing", "reason": "ProgressDeadlineExceeded"}
+    ]
+    with pytest.raises(kr8s.RolloutError):
+        deployment._rollout_progress()
+
+    rc = ReplicationController({"metadata": {"name": "web"}, "spec": {}})
+    with pytest.raises(NotImplementedError):
+        await rc.rollout_restart()
+
+
 async def test_node():
     kubernetes = await kr8s.asyncio.api()
     nodes = await kubernetes.get("nodes")
@@ -418,6 +816,87 @@ async def test_node():
         await node.uncordon()
 
 
+async def test_node_drain_refuses_daemonset_pods():
+    kubernetes = await kr8s.asyncio.api()
+    [node, *_] = await kubernetes.get("nodes")
+    try:
+        with pytest.raises(RuntimeError, match="DaemonSet"):
+            await node.drain()
+        assert node.unschedulable is True
+    finally:
+        await node.uncordon()
+
+
+@pytest.mark.parametrize(
+    "status,cls",
+    [
+        (
+            {
+                "reason": "TooManyRequests",
+                "details": {"causes": [{"reason": "DisruptionBudget"}]},
+            },
+            kr8s.DisruptionBudgetError,
+        ),
+        # Only the Status is used, not the message
+        (
+            {"reason": "TooManyRequests", "message": "violate the disruption budget"},
+            kr8s.TooManyRequestsError,
+        ),
+    ],
+)
+async def test_pod_evict_disruption_budget_status(status, cls):
+    def handler(request):
+        return httpx.Response(429, json={"kind": "Status", "code": 429, **status})
+
+    kubernetes = await kr8s.asyncio.api(
+        url="http://kr8s.test", transport=httpx.MockTransport(handler), retry=False
+    )
+    pod = await Pod({"metadata": {"name": "web", "namespace": "ns"}}, api=kubernetes)
+    with pytest.raises(kr8s.TooManyRequestsError) as e:
+        await pod.evict()
+    assert type(e.value) is cls
+
+
+async def test_node_drain_pod_recreated_with_same_name():
+    requests = []
+
+    def handler(request):
+        requests.append((request.method, request.url.path))
+        if request.url.path == "/api/v1/nodes/node-1":
+            return httpx.Response(200, json={"metadata": {"name": "node-1"}})
+        if request.url.path == "/api/v1/pods":
+            pod = {
+                "metadata": {
+                    "name": "web-0",
+                    "namespace": "default",
+                    "uid": "old",
+                    "ownerReferences": [
+                        {"kind": "StatefulSet", "name": "web", "controller": True}
+                    ],
+                },
+                "spec": {},
+                "status": {"phase": "Running"},
+            }
+            return httpx.Response(200, json={"kind": "PodList", "items": [pod]})
+        if request.url.path.endswith("/eviction"):
+            return httpx.Response(201, json={"kind": "Status", "status": "Success"})
+        # The StatefulSet has already recreated the pod with a new uid
+        return httpx.Response(
+            200,
+            json={
+                "metadata": {"name": "web-0", "namespace": "default", "uid": "new"}
+            },
+        )
+
+    kubernetes = await kr8s.asyncio.api(
+        url="http://kr8s.test", transport=httpx.MockTransport(handler)
+    )
+    node = await Node({"metadata": {"name": "node-1"}}, api=kubernetes)
+    result = await node.drain(timeout=5)
+    assert [pod.name for pod in result["evicted"]] == ["web-0"]
+    assert ("POST", "/api/v1/namespaces/default/pods/web-0/eviction") in requests
+
+
 async def test_service_proxy():
     kubernetes = await kr8s.asyncio.api()
     [service] = await kubernetes.get("services", "kubernetes")
@@ -436,6 +915,153 @@ async def test_pod_logs(example_pod_spec):
     await pod.delete()
 
 
+def test_pod_logs_params():
+    pod = Pod(
+        {
+            "metadata": {"name": "multi"},
+            "spec": {
+                "initContainers": [{"name": "setup"}],
+                "containers": [{"name": "web"}, {"name": "sidecar"}],
+            },
+        }
+    )
+    with pytest.raises(ValueError, match="setup, web, sidecar"):
+        pod._logs_params()
+    with pytest.raises(ValueError, match="not found"):
+        pod._logs_params(container="missing")
+    assert pod._logs_params(container="setup") == {"container": "setup"}
+    assert pod._logs_params(
+        container="web",
+        previous=True,
+        since_time=datetime.datetime(2023, 1, 2, 3, 4, 5, tzinfo=datetime.timezone.utc),
+        tail_lines=10,
+        timestamps=True,
+        limit_bytes=1024,
+    ) == {
+        "container": "web",
+        "previous": "true",
+        "sinceTime": "2023-01-02T03:04:05Z",
+        "tailLines": 10,
+        "timestamps": "true",
+        "limitBytes": 1024,
+    }
+    with pytest.raises(ValueError, match="since_seconds or since_time"):
+        pod._logs_params(container="web", since_seconds=10, since_time="2023-01-01")
+
+    pod.raw["metadata"]["annotations"] = {
+        "kubectl.kubernetes.io/default-container": "sidecar"
+    }
+    assert pod._logs_params(since_seconds=60) == {
+        "container": "sidecar",
+        "sinceSeconds": 60,
+    }
+
+
+async def test_pod_stream_logs(nginx_pod):
+    lines = [line async for line in nginx_pod.stream_logs(tail_lines=5)]
+    assert len(lines) <= 5
+    assert all(isinstance(line, str) for line in lines)
+
+    with anyio.move_on_after(1) as scope:
+        async for _ in nginx_pod.stream_logs(follow=True):
+            pass
+    assert scope.cancelled_caught
+
+
+async def test_pod_exec(nginx_pod):
+    ex = await nginx_pod.exec(["date"])
+    assert ex.returncode == 0
+    assert ex.stdout
+
+    ex = await nginx_pod.exec(["head", "-c", "5"], stdin=b"hello world")
+    assert ex.stdout == b"hello"
+
+    with pytest.raises(kr8s.ExecError) as e:
+        await nginx_pod.exec(["sh", "-c", "echo oops >&2; exit 3"])
+    assert e.value.returncode == 3
+    assert e.value.stderr == b"oops\n"
+
+    ex = await nginx_pod.exec(["false"], check=False)
+    assert ex.returncode == 1
+
+
+async def test_pod_exec_tty(nginx_pod):
+    ex = await nginx_pod.exec(["sh", "-c", "echo hello >&2"], tty=True)
+    assert b"hello" in ex.stdout
+    assert ex.stderr == b""
+
+
+async def test_pod_copy(nginx_pod, tmp_path):
+    src = tmp_path / "src"
+    (src / "sub").mkdir(parents=True)
+    (src / "hello.txt").write_text("hello world")
+    (src / "sub" / "run.sh").write_text("#!/bin/sh\necho hi\n")
+    (src / "sub" / "run.sh").chmod(0o755)
+
+    await nginx_pod.copy_to(src, "/tmp/copied")
+    ex = await nginx_pod.exec(["cat", "/tmp/copied/hello.txt"])
+    assert ex.stdout == b"hello world"
+
+    dest = tmp_path / "dest"
+    await nginx_pod.copy_from("/tmp/copied", dest)
+    assert (dest / "hello.txt").read_text() == "hello world"
+    assert (dest / "sub" / "run.sh").stat().st_mode & 0o777 == 0o755
+
+    await nginx_pod.copy_from("/tmp/copied/hello.txt", tmp_path / "single.txt")
+    assert (tmp_path / "single.txt").read_text() == "hello world"
+
+    with pytest.raises(kr8s.ExecError):
+        await nginx_pod.copy_from("/does/not/exist", tmp_path / "missing")
+    with pytest.raises(FileNotFoundError):
+        await nginx_pod.copy_to(tmp_path / "missing", "/tmp/missing")
+
+
+async def test_pod_debug(nginx_pod):
+    name = await nginx_pod.debug("busybox", ["sleep", "3600"])
+    assert name in [c.name for c in nginx_pod.spec.ephemeralContainers]
+    await nginx_pod.wait(lambda pod: pod._ephemeral_container_running(name))
+    ex = await nginx_pod.exec(["ps"], container=name)
+    assert b"nginx" in ex.stdout
+
+    stdout = io.BytesIO()
+    await nginx_pod.debug(
+        "busybox", ["sh", "-c", "sleep 2; echo hello"], attach=True, stdout=stdout
+    )
+    assert b"hello" in stdout.getvalue()
+
+    with pytest.raises(ValueError, match="not found"):
+        await nginx_pod.debug("busybox", target_container="foo")
+
+
+async def test_pod_attach(example_pod_spec):
+    script = "read name; while true; do echo hello $name; sleep 1; done"
+    example_pod_spec["spec"]["containers"][0].update(
+        image="busybox", command=["sh", "-c", script], stdin=True
+    )
+    pod = await Pod(example_pod_spec)
+    await pod.create()
+    await pod.wait_ready(timeout=60)
+    stdout = io.BytesIO()
+    result = await pod.attach(stdin=b"kr8s\n", stdout=stdout, timeout=5)
+    assert result.returncode is None
+    assert b"hello kr8s" in result.stdout
+    assert result.stdout == stdout.getvalue()
+    await pod.delete()
+
+
+@pytest.mark.parametrize("name", ["../evil", "dir/../../evil", "/etc/evil"])
+def test_extract_tar_rejects_unsafe_paths(tmp_path, name):
+    archive = io.BytesIO()
+    with tarfile.open(fileobj=archive, mode="w") as tar:
+        info = tarfile.TarInfo(name)
+        info.size = 4
+        tar.addfile(info, io.BytesIO(b"evil"))
+    archive.seek(0)
+    with pytest.raises(ValueError, match="Refusing"):
+        _extract_tar(archive, tmp_path / "dest")
+    assert not (tmp_path / "evil").exists()
+
+
 async def test_pod_port_forward_context_manager(nginx_service):
     [nginx_pod, *_] = await nginx_service.ready_pods()
     async with nginx_pod.portforward(80) as port:
@@ -503,6 +1129,28 @@ async def test_service_port_forward_start_stop(nginx_service):
     assert pf._bg_task is None
 
 
+async def test_service_port_forward_named_port(example_service_spec, nginx_pod):
+    example_service_spec["metadata"]["name"] = nginx_pod.name
+    example_service_spec["spec"]["selector"] = nginx_pod.labels
+    example_service_spec["spec"]["ports"] = [
+        {"name": "http", "port": 8080, "targetPort": 80}
+    ]
+    service = await Service(example_service_spec)
+    await service.create()
+    while not await service.ready():
+        await asyncio.sleep(0.1)  # pragma: no cover
+
+    async with service.portforward("http") as port:
+        async with httpx.AsyncClient(timeout=DEFAULT_TIMEOUT) as session:
+            resp = await session.get(f"http://localhost:{port}/")
+            assert resp.status_code == 200
+
+    with pytest.raises(ValueError, match="no port named"):
+        async with service.portforward("https"):
+            pass  # pragma: no cover
+    await service.delete()
+
+
 async def test_unsupported_port_forward():
     pv = await PersistentVolume({})
     with pytest.raises(AttributeError):
@@ -564,13 +1212,110 @@ async def test_objects_from_files_nested():
     assert any(isinstance(o, Ingress) for o in objects)
 
 
+async def test_objects_from_yaml_and_json(example_pod_spec):
+    example_pod_spec["metadata"]["managedFields"] = [{"manager": "kubectl"}]
+    pod = await Pod(example_pod_spec)
+
+    manifest = pod.to_yaml(strip_managed_fields=True)
+    assert "managedFields" not in manifest
+    assert manifest.index("apiVersion") < manifest.index("metadata")
+    assert "managedFields" in pod.to_yaml()
+    features = "apiVersion: foo.kr8s.org/v1\nkind: Feature\nmetadata:\n  name: bar\n"
+    objects = objects_from_yaml(manifest + "---\n" + features)
+    assert len(objects) == 2
+    assert isinstance(objects[0], Pod)
+    assert objects[0].raw == pod._manifest(strip_managed_fields=True)
+    assert objects[1].kind == "Feature"
+    assert objects[1].version == "foo.kr8s.org/v1"
+
+    items = {"apiVersion": "v1", "kind": "List", "items": [pod.raw, pod.raw]}
+    objects = objects_from_json(json.dumps(items))
+    assert len(objects) == 2
+    assert all(isinstance(o, Pod) for o in objects)
+    [obj] = objects_from_json(pod.to_json())
+    assert obj.raw == pod.raw
+
+    [obj] = kr8s.objects.objects_from_yaml(manifest)
+    assert isinstance(obj, SyncPod)
+
+
 async def test_custom_object_from_file():
     simple_dir = CURRENT_DIR / "resources" / "custom" / "evc.yaml"
     objects = await objects_from_files(simple_dir)
     assert len(objects) == 1
 
 
+async def test_jsonpath(example_pod_spec):
+    example_pod_spec["status"] = {
+        "phase": "Running",
+        "conditions": [
+            {"type": "Initialized", "status": "True"},
+            {"type": "Ready", "status": "False"},
+        ],
+    }
+    pod = Pod(example_pod_spec)
+    assert pod.jsonpath("{.status.phase}") == "Running"
+    assert pod.jsonpath(".metadata.name") == pod.name
+    assert pod.jsonpath('{.status.conditions[?(@.type=="Ready")].status}') == "False"
+    assert pod.jsonpath("{.status.conditions[*].type}") == ["Initialized", "Ready"]
+    assert pod.jsonpath_all("{.status.phase}") == ["Running"]
+    assert pod.jsonpath_all("{.status.missing}") == []
+    assert pod.jsonpath("{.status.missing}", default=None) is None
+    with pytest.raises(KeyError, match="status.missing"):
+        pod.jsonpath("{.status.missing}")
+    with pytest.raises(ValueError):
+        pod.jsonpath("{.status[}")
+
+
+async def test_jsonpath_typed(example_pod_spec):
+    example_pod_spec["spec"]["terminationGracePeriodSeconds"] = 30
+    example_pod_spec["spec"]["hostNetwork"] = False
+    example_pod_spec["status"] = {
+        "phase": "Running",
+        "conditions": [
+            {"type": "Initialized", "status": "True"},
+            {"type": "Ready", "status": "False"},
+        ],
+    }
+    pod = Pod(example_pod_spec)
+    assert pod.jsonpath_str("{.status.phase}") == "Running"
+    assert pod.jsonpath_int("{.spec.terminationGracePeriodSeconds}") == 30
+    assert pod.jsonpath_bool("{.spec.hostNetwork}") is False
+    assert pod.jsonpath_str("{.status.missing}", default="") == ""
+    with pytest.raises(KeyError, match="status.missing"):
+        pod.jsonpath_int("{.status.missing}")
+    with pytest.raises(TypeError, match="expected int"):
+        pod.jsonpath_int("{.status.phase}")
+    with pytest.raises(TypeError, match="expected int"):
+        pod.jsonpath_int("{.spec.hostNetwork}")
+    with pytest.raises(TypeError, match="expected bool"):
+        pod.jsonpath_bool('{.status.conditions[?(@.type=="Ready")].status}')
+    with pytest.raises(TypeError, match="matched 2 values"):
+        pod.jsonpath_str("{.status.conditions[*].type}")
+
+
 async def test_pod_to_dict(example_pod_spec):
     pod = Pod(example_pod_spec)
     assert dict(pod) == example_pod_spec
     assert dict(pod) == pod.raw
+
+
+def test_extract_tar_modes(tmp_path):
+    archive = io.BytesIO()
+    with tarfile.open(fileobj=archive, mode="w") as tar:
+        info = tarfile.TarInfo("src")
+        info.type = tarfile.DIRTYPE
+        info.mode = 0o1555
+        tar.addfile(info)
+        info = tarfile.TarInfo("src/run")
+        info.size = 4
+        info.mode = 0o4755
+        tar.addfile(info, io.BytesIO(b"echo"))
+    archive.seek(0)
+    _extract_tar(archive, tmp_path / "dest")
+    try:
+        assert (tmp_path / "dest" / "run").read_bytes() == b"echo"
+        assert (tmp_path / "dest" / "run").stat().st_mode & 0o7777 == 0o755
+        assert (tmp_path / "dest").stat().st_mode & 0o7777 == 0o555
+    finally:
+        (tmp_path / "dest").chmod(0o755)
@@ -0,0 +1,70 @@
+# SPDX-FileCopyrightText: Copyright (c) 2023, Dask Developers, NVIDIA
+# SPDX-License-Identifier: BSD 3-Clause License
+import threading
+import time
+
+import anyio
+import pytest
+
+import kr8s.asyncio
+from kr8s import RateLimiter
+
+
+async def test_rate_limiter_burst():
+    limiter = RateLimiter(qps=10, burst=3)
+    start = time.monotonic()
+    for _ in range(3):
+        await limiter.acquire()
+    assert limiter.throttled == 0
+    await limiter.acquire()
+    assert limiter.throttled == 1
+    assert time.monotonic() - start >= 0.09
+
+
+async def test_rate_limiter_on_throttle():
+    delays = []
+    limiter = RateLimiter(qps=20, burst=1, on_throttle=delays.append)
+    for _ in range(3):
+        await limiter.acquire()
+    assert len(delays) == 2
+    assert limiter.throttled == 2
+    assert limiter.throttled_seconds == pytest.approx(sum(delays))
+
+
+async def test_rate_limiter_cancel_returns_token():
+    limiter = RateLimiter(qps=1, burst=1)
+    await limiter.acquire()
+    with anyio.move_on_after(0.1):
+        await limiter.acquire()
+    assert limiter._reserve() < 1.5
+
+
+def test_rate_limiter_threads():
+    # Sync clients share a limiter between event loop threads
+    limiter = RateLimiter(qps=0.001, burst=1)
+
+    def reserve():
+        for _ in range(1000):
+            limiter._reserve()
+
+    threads = [threading.Thread(target=reserve) for _ in range(4)]
+    for thread in threads:
+        thread.start()
+    for thread in threads:
+        thread.join()
+    assert limiter.throttled == 3999
+
+
+def test_rate_limiter_invalid():
+    with pytest.raises(ValueError):
+        RateLimiter(qps=0)
+    with pytest.raises(ValueError):
+        RateLimiter(burst=0)
+
+
+async def test_api_rate_limit():
+    limiter = RateLimiter(qps=5, burst=1)
+    kubernetes = await kr8s.asyncio.api(rate_limit=limiter)
+    for _ in range(3):
+        await kubernetes.version()
+    assert limiter.throttled >= 2
@@ -0,0 +1,74 @@
+# SPDX-FileCopyrightText: Copyright (c) 2023, Dask Developers, NVIDIA
+# SPDX-License-Identifier: BSD 3-Clause License
+import email.utils
+import time
+
+import httpx
+import pytest
+
+import kr8s
+import kr8s.asyncio
+from kr8s import RetryPolicy
+
+
+def make_response(status_code, headers=None):
+    return httpx.Response(
+        status_code, headers=headers, request=httpx.Request("GET", "https://k8s")
+    )
+
+
+def test_retry_idempotent_only():
+    policy = RetryPolicy()
+    assert policy.should_retry_response("GET", make_response(503))
+    assert not policy.should_retry_response("GET", make_response(404))
+    assert not policy.should_retry_response("POST", make_response(503))
+    assert not policy.should_retry_response("PATCH", make_response(500))
+
+
+def test_retry_exceptions():
+    policy = RetryPolicy()
+    request = httpx.Request("POST", "https://k8s")
+    assert policy.should_retry_exception("GET", httpx.ReadError("reset"))
+    assert not policy.should_retry_exception("POST", httpx.ReadError("reset"))
+    assert policy.should_retry_exception(
+        "POST", httpx.ConnectError("refused", request=request)
+    )
+    assert not policy.should_retry_exception("GET", ValueError("not transient"))
+
+
+def test_retry_backoff():
+    policy = RetryPolicy(backoff_base=1, backoff_max=5)
+    assert 0.5 <= policy.delay(1) <= 1
+    assert 1 <= policy.delay(2) <= 2
+    assert 2.5 <= policy.delay(10) <= 5
+
+
+def test_retry_after():
+    policy = RetryPolicy(backoff_max=60)
+    assert policy.delay(1, make_response(429, {"Retry-After": "7"})) == 7
+    assert policy.delay(1, make_response(503, {"Retry-After": "120"})) == 60
+    retry_at = email.utils.formatdate(time.time() + 10, usegmt=True)
+    assert 8 < policy.delay(1, make_response(503, {"Retry-After": retry_at})) <= 10
+    # Only honoured on 429 and 503
+    assert policy.delay(1, make_response(500, {"Retry-After": "7"})) <= 0.5
+
+
+def test_retry_policy_is_hashable():
+    assert hash(RetryPolicy()) == hash(RetryPolicy())
+
+
+async def test_api_retry_policy():
+    policy = RetryPolicy(max_attempts=2)
+    api = await kr8s.asyncio.api(retry=policy)
+    assert api._retry is policy
+    assert await kr8s.asyncio.api(retry=policy) is api
+
+    api = await kr8s.asyncio.api(retry=False)
+    assert api._retry.max_attempts == 1
+
+
+@pytest.mark.parametrize("retry", [None, False])
+async def test_api_retry_requests(retry):
+    api = await kr8s.asyncio.api(retry=retry)
+    version = await api.version()
+    assert "major" in version
@@ -0,0 +1,125 @@
+# SPDX-FileCopyrightText: Copyright (c) 2023, Dask Developers, NVIDIA
+# SPDX-License-Identifier: BSD 3-Clause License
+import pytest
+
+from kr8s import FieldSelector, LabelSelector
+
+
+def test_label_selector():
+    selector = (
+        LabelSelector()
+        .equals("app", "nginx")
+        .in_("tier", "web", "api")
+        .not_exists("deprecated")
+    )
+    assert str(selector) == "app=nginx,tier in (web,api),!deprecated"
+    selector = selector.not_equals("env", "dev").not_in("zone", "a").exists("x")
+    assert str(selector).endswith(",env!=dev,zone notin (a),x")
+
+
+def test_label_selector_is_immutable():
+    base = LabelSelector().equals("app", "nginx")
+    extended = base.exists("tier")
+    assert str(base) == "app=nginx"
+    assert str(extended) == "app=nginx,tier"
+    assert str(base & LabelSelector().exists("tier")) == str(extended)
+
+
+@pytest.mark.parametrize(
+    "selector",
+    [
+        "app=nginx",
+        "app.kubernetes.io/name=nginx,tier in (web,api)",
+        "env notin (dev,test),!deprecated,release",
+        "a!=b,c=",
+    ],
+)
+def test_label_selector_round_trip(selector):
+    parsed = LabelSelector.parse(selector)
+    assert str(parsed) == selector
+    assert LabelSelector.parse(str(parsed)) == parsed
+
+
+def test_label_selector_parse_normalizes():
+    assert str(LabelSelector.parse("a == b , c notin ( x, y ), ! d")) == (
+        "a=b,c notin (x,y),!d"
+    )
+
+
+@pytest.mark.parametrize(
+    "key", ["", "-app", "a/b/c", "Example.com/app", "/app", "a" * 64, "app name"]
+)
+def test_label_selector_invalid_key(key):
+    with pytest.raises(ValueError, match="Invalid label key"):
+        LabelSelector().exists(key)
+
+
+@pytest.mark.parametrize("value", ["-nginx", "a,b", "a=b", "a" * 64])
+def test_label_selector_invalid_value(value):
+    with pytest.raises(ValueError, match="Invalid label value"):
+        LabelSelector().equals("app", value)
+
+
+def test_label_selector_invalid():
+    with pytest.raises(ValueError):
+        LabelSelector().in_("tier")
+    with pytest.raises(ValueError):
+        LabelSelector.parse("tier in web")
+
+
+def test_label_selector_from_spec():
+    spec = {
+        "matchLabels": {"app": "nginx"},
+        "matchExpressions": [
+            {"key": "tier", "operator": "In", "values": ["web"]},
+            {"key": "env", "operator": "NotIn", "values": ["dev"]},
+            {"key": "release", "operator": "Exists"},
+            {"key": "deprecated", "operator": "DoesNotExist"},
+        ],
+    }
+    assert str(LabelSelector.from_spec(spec)) == (
+        "app=nginx,tier in (web),env notin (dev),release,!deprecated"
+    )
+    assert str(LabelSelector.from_dict({"a": "b", "c": "d"})) == "a=b,c=d"
+    assert str(LabelSelector.from_dict({"replicas": 3})) == "replicas=3"
+
+
+def test_field_selector():
+    selector = FieldSelector().equals("status.phase", "Running")
+    assert str(selector) == "status.phase=Running"
+    selector = selector.not_equals("spec.nodeName", "node-1")
+    assert str(selector) == "status.phase=Running,spec.nodeName!=node-1"
+    assert str(FieldSelector.from_dict({"metadata.name": "foo"})) == (
+        "metadata.name=foo"
+    )
+    assert str(FieldSelector.from_dict({"spec.replicas": 3})) == "spec.replicas=3"
+
+
+def test_field_selector_escaping():
+    selector = FieldSelector().equals("metadata.name", "a,b=c\\d")
+    assert str(selector) == "metadata.name=a\\,b\\=c\\\\d"
+    assert FieldSelector.parse(str(selector)) == selector
+
+
+@pytest.mark.parametrize(
+    "selector",
+    [
+        "status.phase=Running",
+        "status.phase!=Pending,metadata.namespace=default",
+        "metadata.name=a\\,b",
+        "spec.nodeName=",
+    ],
+)
+def test_field_selector_round_trip(selector):
+    parsed = FieldSelector.parse(selector)
+    assert str(parsed) == selector
+    assert FieldSelector.parse(str(parsed)) == parsed
+
+
+def test_field_selector_invalid():
+    with pytest.raises(ValueError):
+        FieldSelector().equals("status phase", "Running")
+    with pytest.raises(ValueError):
+        FieldSelector.parse("status.phase")
+    with pytest.raises(ValueError):
+        FieldSelector.parse("metadata.name=a\\b")